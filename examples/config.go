@@ -0,0 +1,34 @@
+package examples
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// ConfigDatabase is the config to be bound from the properties with the prefix database
+type ConfigDatabase struct {
+	// Host is bound to database.host (env: DATABASE_HOST) and must be provided
+	Host string `config:"host,required"`
+	// Port is bound to database.port (env: DATABASE_PORT) and defaults to 5432
+	Port int `config:"port,default=5432"`
+}
+
+// ConfigConsumer is the consumer for ConfigDatabase
+type ConfigConsumer struct {
+	// Config will be injected as any other component
+	Config *ConfigDatabase `inject:""`
+}
+
+var _ = Describe("Config example", func() {
+	It("should wire the bound config", func() {
+		// KINDLY NOTE: without Properties, the environment variables are used
+		scope := &di.Scope{Properties: di.MapProperties{
+			"database": map[string]interface{}{"host": "localhost"},
+		}}
+		scope.MustRegisterConfig(&ConfigDatabase{}, "database")
+		instance := &ConfigConsumer{}
+		scope.MustWire(instance)
+		Expect(instance).To(Equal(&ConfigConsumer{Config: &ConfigDatabase{Host: "localhost", Port: 5432}}))
+	})
+})
@@ -0,0 +1,40 @@
+package examples
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// InvokeDependency is the interface for a dependency to be injected
+type InvokeDependency interface {
+	Woop()
+}
+
+// InvokeComponent is the component to be injected
+type InvokeComponent struct{ Name string }
+
+func (c *InvokeComponent) Woop() {}
+
+// InvokeParams is a parameter object, allowing qualifiers and optional dependencies for parameters
+type InvokeParams struct {
+	// Dependency will be injected with qualifier
+	Dependency InvokeDependency `inject:"qualifier=squash"`
+	// Missing cannot be resolved
+	Missing InvokeDependency `inject:"optional,qualifier=soccer"`
+}
+
+var _ = Describe("Invoke example", func() {
+	It("should call the function with resolved parameters", func() {
+		scope := &di.Scope{}
+		scope.MustRegister(&InvokeComponent{"squash"}).WithQualifier("squash")
+		var invoked InvokeParams
+		// KINDLY NOTE: the error (if any) of the function is returned by Invoke
+		_, err := scope.Invoke(func(params InvokeParams) error {
+			invoked = params
+			return nil
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(invoked).To(Equal(InvokeParams{Dependency: &InvokeComponent{Name: "squash"}}))
+	})
+})
@@ -0,0 +1,37 @@
+package examples
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// MethodDependency is the interface for a dependency to be injected
+type MethodDependency interface {
+	Woop()
+}
+
+// MethodComponent is the component to be injected
+type MethodComponent struct{ Name string }
+
+func (c *MethodComponent) Woop() {}
+
+// MethodConsumer is the consumer for MethodDependency, keeping it private
+type MethodConsumer struct {
+	dependency MethodDependency
+}
+
+// InjectDependency will be called on wiring, since it is prefixed with Inject and Scope#InjectMethods is enabled
+func (c *MethodConsumer) InjectDependency(dependency MethodDependency) {
+	c.dependency = dependency
+}
+
+var _ = Describe("Method injection example", func() {
+	It("should call the inject method", func() {
+		scope := &di.Scope{InjectMethods: true}
+		scope.MustRegister(&MethodComponent{"squash"})
+		instance := &MethodConsumer{}
+		scope.MustWire(instance)
+		Expect(instance).To(Equal(&MethodConsumer{dependency: &MethodComponent{Name: "squash"}}))
+	})
+})
@@ -0,0 +1,55 @@
+package examples
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+// ObjectsDependency is the interface for a dependency to be injected
+type ObjectsDependency interface {
+	Woop()
+}
+
+// ObjectsComponent is the component to be injected
+type ObjectsComponent struct{ Name string }
+
+func (c *ObjectsComponent) Woop() {}
+
+// ObjectsParams is the parameter object for the factory
+type ObjectsParams struct {
+	di.In
+	// Name will be injected with qualifier
+	Name string `inject:"qualifier=name"`
+}
+
+// ObjectsResults is the result object of the factory, each field is registered as a component
+type ObjectsResults struct {
+	di.Out
+	// Squash is registered with the qualifier squash
+	Squash ObjectsDependency `inject:"qualifier=squash"`
+	// Soccer is registered with the qualifier soccer
+	Soccer ObjectsDependency `inject:"qualifier=soccer"`
+}
+
+// ObjectsConsumer is the consumer for ObjectsDependency
+type ObjectsConsumer struct {
+	// Dependency will be injected with qualifier
+	Dependency ObjectsDependency `inject:"qualifier=soccer"`
+}
+
+var _ = Describe("Parameter and result objects example", func() {
+	It("should wire components provided by the factory", func() {
+		scope := &di.Scope{}
+		scope.MustRegister("ball").WithQualifier("name")
+		scope.MustRegister(func(params ObjectsParams) ObjectsResults {
+			return ObjectsResults{
+				Squash: &ObjectsComponent{"squash " + params.Name},
+				Soccer: &ObjectsComponent{"soccer " + params.Name},
+			}
+		})
+		instance := &ObjectsConsumer{}
+		scope.MustWire(instance)
+		Expect(instance).To(Equal(&ObjectsConsumer{Dependency: &ObjectsComponent{Name: "soccer ball"}}))
+	})
+})
@@ -31,4 +31,14 @@ var _ = Describe("Parent wiring example", func() {
 		scope.MustWire(instance)
 		Expect(instance).To(Equal(&ParentConsumer{Dependency: &ParentComponent{Name: "squash"}}))
 	})
+	It("should wire components of the child first", func() {
+		parent := &di.Scope{}
+		parent.MustRegister(&ParentComponent{"squash"}).WithPriority(-1)
+		// the child's registrations shadow the parent's, regardless of the priority
+		scope := parent.NewChild()
+		scope.MustRegister(&ParentComponent{"soccer"})
+		instance := &ParentConsumer{}
+		scope.MustWire(instance)
+		Expect(instance).To(Equal(&ParentConsumer{Dependency: &ParentComponent{Name: "soccer"}}))
+	})
 })
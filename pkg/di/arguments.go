@@ -0,0 +1,65 @@
+package di
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// resolveArguments resolves the values for the parameter types using the InstanceResolver
+func resolveArguments(resolver InstanceResolver, params []reflect.Type) ([]reflect.Value, error) {
+	result := make([]reflect.Value, len(params))
+	for idx, param := range params {
+		resolved, err := resolveArgument(resolver, param)
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not resolve parameter %v", idx)
+		}
+		if !resolved.IsValid() {
+			resolved = reflect.Zero(param)
+		}
+		result[idx] = resolved
+	}
+	return result, nil
+}
+
+func resolveArgument(resolver InstanceResolver, param reflect.Type) (reflect.Value, error) {
+	if param == contextType {
+		return reflect.ValueOf(contextOf(resolver)), nil
+	}
+	if !IsParameterObject(param) {
+		return resolver.ResolveInstance(param, TagValue{Required: true})
+	}
+	injectable, err := InjectableFrom(param)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	result := reflect.New(param)
+	if err = injectable.Apply(result, resolver); err != nil {
+		return reflect.Value{}, err
+	}
+	return result.Elem(), nil
+}
+
+// registrationResolver is implemented by resolvers creating the instances of registrations like resolved ones, i.e.
+// waiting for concurrent creations and wiring them
+type registrationResolver interface {
+	resolveRegistration(registration *Registration) (interface{}, error)
+}
+
+// resolveRegistration returns the instance of the registration using the resolver, e.g. the owner of a result
+func resolveRegistration(resolver InstanceResolver, registration *Registration) (interface{}, error) {
+	if registrations, ok := resolver.(registrationResolver); ok {
+		return registrations.resolveRegistration(registration)
+	}
+	instance, _, err := registration.GetInstanceFrom(resolver)
+	return instance, err
+}
+
+// parametersOf returns the parameter types of a function type, skipping the first n parameters
+func parametersOf(fnType reflect.Type, skip int) []reflect.Type {
+	result := make([]reflect.Type, 0, fnType.NumIn())
+	for i := skip; i < fnType.NumIn(); i++ {
+		result = append(result, fnType.In(i))
+	}
+	return result
+}
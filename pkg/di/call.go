@@ -0,0 +1,113 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+var (
+	_ InstanceResolver     = &callResolver{}
+	_ injectionObserver    = &callResolver{}
+	_ registrationResolver = &callResolver{}
+	_ contextProvider      = &callResolver{}
+)
+
+// call is the state of a single call resolving instances, e.g. Scope#Wire, Scope#ResolveInstance or a worker of
+// Scope#InstantiateAll. It is passed down the call chain, so that concurrent calls on the same scope don't interfere.
+type call struct {
+	// ctx is the context of the call, containing the current span while tracing
+	ctx context.Context
+	// consumers is the stack of components resolving their dependencies
+	consumers []activeConsumer
+	// nestedTimes is the stack of the times spent in nested factory calls and wirings, see timing
+	nestedTimes []time.Duration
+	// parallel is set for the calls of the workers instantiating registrations concurrently
+	parallel *parallelInstantiation
+}
+
+// activeConsumer is a component resolving its dependencies, tracked by call#consuming
+type activeConsumer struct {
+	tpe reflect.Type
+	// module is the module of the component, nil outside of modules
+	module *Module
+}
+
+// consuming tracks the type currently resolving its dependencies and returns the function to stop tracking it
+func (c *call) consuming(tpe reflect.Type, module *Module) func() {
+	c.consumers = append(c.consumers, activeConsumer{tpe: tpe, module: module})
+	return func() {
+		c.consumers = c.consumers[:len(c.consumers)-1]
+	}
+}
+
+// consumer returns the type currently resolving its dependencies, nil if resolved directly
+func (c *call) consumer() reflect.Type {
+	if len(c.consumers) == 0 {
+		return nil
+	}
+	return c.consumers[len(c.consumers)-1].tpe
+}
+
+// consumerModule returns the module of the component currently resolving its dependencies, nil outside of modules
+func (c *call) consumerModule() *Module {
+	if len(c.consumers) == 0 {
+		return nil
+	}
+	return c.consumers[len(c.consumers)-1].module
+}
+
+// timing starts measuring a factory call or wiring, the returned function returns the total time and the time spent
+// excluding nested factory calls and wirings
+func (c *call) timing() func() (total, self time.Duration) {
+	start := time.Now()
+	c.nestedTimes = append(c.nestedTimes, 0)
+	return func() (time.Duration, time.Duration) {
+		total := time.Since(start)
+		last := len(c.nestedTimes) - 1
+		self := total - c.nestedTimes[last]
+		c.nestedTimes = c.nestedTimes[:last]
+		if last > 0 {
+			c.nestedTimes[last-1] += total
+		}
+		return total, self
+	}
+}
+
+// wait returns the function to wait for the creations of other creators, see Registration#beginCreation
+func (c *call) wait() func() {
+	if c.parallel != nil {
+		return c.parallel.waitCreation
+	}
+	return nil
+}
+
+// canceled returns the error of the context of the call, if it is done
+func (c *call) canceled() error {
+	if c.ctx == nil {
+		return nil
+	}
+	return c.ctx.Err()
+}
+
+// callResolver resolves the instances of the scope within the call, it is passed to factories and inject methods
+type callResolver struct {
+	scope *Scope
+	call  *call
+}
+
+func (r *callResolver) ResolveInstance(tpe reflect.Type, tag TagValue) (reflect.Value, error) {
+	return r.scope.resolveInstance(r.call, tpe, tag)
+}
+
+func (r *callResolver) resolveRegistration(registration *Registration) (interface{}, error) {
+	return r.scope.wiredInstance(r.call, registration)
+}
+
+func (r *callResolver) context() context.Context {
+	return r.call.ctx
+}
+
+func (r *callResolver) fieldInjected(target reflect.Value, injection Injection, value reflect.Value) {
+	r.scope.fieldInjected(target, injection, value)
+}
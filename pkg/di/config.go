@@ -0,0 +1,156 @@
+package di
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	ConfigTagKey           = "config"
+	ConfigTagValueRequired = "required"
+	ConfigTagPrefixDefault = "default="
+	ConfigTagValueSkip     = "-"
+)
+
+// ConfigField represents a single field of a config struct bound to a property
+type ConfigField struct {
+	// Key is the full property key, e.g. database.host
+	Key string
+	// Default is the raw default value being used, if the property is not found
+	Default string
+	// HasDefault denotes if a default was provided
+	HasDefault bool
+	// Required denotes if the property must be provided, if there is no default
+	Required bool
+}
+
+// ConfigFieldFrom creates a ConfigField for a struct field, using the field name (lower case) if the tag has no name
+func ConfigFieldFrom(prefix string, fld reflect.StructField) ConfigField {
+	parts := strings.Split(fld.Tag.Get(ConfigTagKey), ",")
+	name := parts[0]
+	if len(name) == 0 {
+		name = strings.ToLower(fld.Name)
+	}
+	result := ConfigField{Key: name}
+	if len(prefix) > 0 {
+		result.Key = prefix + PropertyKeySeparator + name
+	}
+	for _, part := range parts[1:] {
+		if part == ConfigTagValueRequired {
+			result.Required = true
+		}
+		if strings.HasPrefix(part, ConfigTagPrefixDefault) {
+			result.Default, result.HasDefault = part[len(ConfigTagPrefixDefault):], true
+		}
+	}
+	return result
+}
+
+// BindConfig populates the struct ptr target from the PropertySource using the property prefix
+func BindConfig(target interface{}, prefix string, source PropertySource) error {
+	val := reflect.ValueOf(target)
+	if val.Kind() != reflect.Ptr || val.Elem().Kind() != reflect.Struct {
+		return errNoStructPtr(reflect.TypeOf(target))
+	}
+	var missing []string
+	if err := bindStruct(val.Elem(), prefix, source, &missing); err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return errMissingProperties(val.Type(), missing)
+	}
+	return nil
+}
+
+func bindStruct(target reflect.Value, prefix string, source PropertySource, missing *[]string) error {
+	tpe := target.Type()
+	for i := 0; i < tpe.NumField(); i++ {
+		structFld := tpe.Field(i)
+		if !structFld.IsExported() || structFld.Tag.Get(ConfigTagKey) == ConfigTagValueSkip {
+			continue
+		}
+		field := ConfigFieldFrom(prefix, structFld)
+		if structFld.Type.Kind() == reflect.Struct && structFld.Type != reflect.TypeOf(time.Time{}) {
+			if err := bindStruct(target.Field(i), field.Key, source, missing); err != nil {
+				return err
+			}
+			continue
+		}
+		raw, found := source.Property(field.Key)
+		if !found {
+			if field.Required && !field.HasDefault {
+				*missing = append(*missing, field.Key)
+			}
+			if !field.HasDefault {
+				continue
+			}
+			raw = field.Default
+		}
+		if err := setProperty(target.Field(i), raw); err != nil {
+			if errors.Is(err, errUnsupported) {
+				return errUnsupportedConfigField(tpe, structFld)
+			}
+			return errors.Wrapf(err, "could not bind property: %v", field.Key)
+		}
+	}
+	return nil
+}
+
+var errUnsupported = errors.New("unsupported type")
+
+func setProperty(fld reflect.Value, raw string) error {
+	if fld.Type() == reflect.TypeOf(time.Duration(0)) {
+		duration, err := time.ParseDuration(raw)
+		if err == nil {
+			fld.SetInt(int64(duration))
+		}
+		return err
+	}
+	switch fld.Kind() {
+	case reflect.String:
+		fld.SetString(raw)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+		fld.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, fld.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fld.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, fld.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fld.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, fld.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fld.SetFloat(parsed)
+	case reflect.Slice:
+		var parts []string
+		if len(raw) > 0 {
+			parts = strings.Split(raw, ",")
+		}
+		result := reflect.MakeSlice(fld.Type(), len(parts), len(parts))
+		for idx, part := range parts {
+			if err := setProperty(result.Index(idx), strings.TrimSpace(part)); err != nil {
+				return err
+			}
+		}
+		fld.Set(result)
+	default:
+		return errUnsupported
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package di_test
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type PoolConfig struct {
+	Size    int           `config:"size,default=4"`
+	Timeout time.Duration `config:"timeout"`
+}
+
+type DBConfig struct {
+	Host     string `config:",required"`
+	Port     uint16 `config:"port,default=5432"`
+	Replicas []string
+	Debug    bool
+	Ratio    float64
+	Pool     PoolConfig
+	Ignored  string `config:"-"`
+	internal string // nolint: structcheck,unused
+}
+
+var _ = Describe("ConfigFieldFrom()", func() {
+	It("should use the lower case field name", func() {
+		fld, _ := reflect.TypeOf(DBConfig{}).FieldByName("Host")
+		Expect(di.ConfigFieldFrom("database", fld)).To(Equal(di.ConfigField{Key: "database.host", Required: true}))
+	})
+	It("should parse name and default", func() {
+		fld, _ := reflect.TypeOf(DBConfig{}).FieldByName("Port")
+		Expect(di.ConfigFieldFrom("", fld)).To(Equal(di.ConfigField{Key: "port", Default: "5432", HasDefault: true}))
+	})
+})
+
+var _ = Describe("BindConfig()", func() {
+	var properties di.MapProperties
+	BeforeEach(func() {
+		properties = di.MapProperties{"database": map[string]interface{}{
+			"host":     "localhost",
+			"replicas": []interface{}{"a", "b"},
+			"debug":    true,
+			"ratio":    0.5,
+			"ignored":  "meh",
+			"pool":     map[interface{}]interface{}{"timeout": "1s"},
+		}}
+	})
+	It("should bind all properties", func() {
+		cfg := &DBConfig{}
+		Expect(di.BindConfig(cfg, "database", properties)).To(Succeed())
+		Expect(cfg).To(Equal(&DBConfig{
+			Host:     "localhost",
+			Port:     5432,
+			Replicas: []string{"a", "b"},
+			Debug:    true,
+			Ratio:    0.5,
+			Pool:     PoolConfig{Size: 4, Timeout: time.Second},
+		}))
+	})
+	It("should error on missing required properties", func() {
+		Expect(di.BindConfig(&DBConfig{}, "other", properties)).To(MatchError(And(
+			ContainSubstring("missing required properties"),
+			ContainSubstring("other.host"),
+		)))
+	})
+	It("should error on invalid values", func() {
+		properties["database"].(map[string]interface{})["port"] = "meh"
+		Expect(di.BindConfig(&DBConfig{}, "database", properties)).To(MatchError(
+			ContainSubstring("could not bind property: database.port"),
+		))
+	})
+	It("should error on unsupported types", func() {
+		Expect(di.BindConfig(&struct{ A map[string]string }{}, "", di.MapProperties{"a": "b"})).To(MatchError(
+			ContainSubstring("unsupported config field type"),
+		))
+	})
+	It("should error if not struct ptr", func() {
+		Expect(di.BindConfig(DBConfig{}, "", properties)).To(MatchError(ContainSubstring("expected a struct pointer")))
+	})
+})
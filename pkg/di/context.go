@@ -0,0 +1,89 @@
+package di
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// contextType is the type of context.Context, which may be the first parameter of factories and inject methods
+var contextType = reflect.TypeOf((*context.Context)(nil)).Elem()
+
+var _ contextProvider = &parallelResolver{}
+
+// contextProvider is implemented by resolvers providing the context of the current resolution
+type contextProvider interface {
+	context() context.Context
+}
+
+// contextOf returns the context of the current resolution of the resolver, context.Background() if unknown
+func contextOf(resolver InstanceResolver) context.Context {
+	if provider, ok := resolver.(contextProvider); ok {
+		if ctx := provider.context(); ctx != nil {
+			return ctx
+		}
+	}
+	return context.Background()
+}
+
+// CanceledError denotes the creation of a component has been canceled or its deadline has been exceeded
+type CanceledError struct {
+	// Registration is the registration of the component being created
+	Registration *Registration
+	// Err is the error of the creation
+	Err error
+}
+
+func (e *CanceledError) Error() string {
+	return fmt.Sprintf("creation canceled: %v: %v", e.Registration, e.Err)
+}
+
+func (e *CanceledError) Cause() error {
+	return e.Err
+}
+
+func (e *CanceledError) Unwrap() error {
+	return e.Err
+}
+
+// canceled returns a CanceledError for the failed creation of the registration if the context is done, unless the
+// error already denotes the canceled creation of a dependency
+func canceled(ctx context.Context, registration *Registration, err error) error {
+	var canceledErr *CanceledError
+	if ctx == nil || ctx.Err() == nil || errors.As(err, &canceledErr) {
+		return err
+	}
+	return &CanceledError{Registration: registration, Err: err}
+}
+
+// WireContext works like Wire, passing the context to factories with a context.Context as first parameter.
+// Once the context is done, no further factories are called and the error denotes the component being created.
+func (s *Scope) WireContext(ctx context.Context, targets ...interface{}) error {
+	return s.wire(&call{ctx: ctx}, targets...)
+}
+
+// ResolveContext works like ResolveInstance, passing the context to factories with a context.Context as first
+// parameter. Once the context is done, no further factories are called and the error denotes the component being
+// created.
+func (s *Scope) ResolveContext(ctx context.Context, tpe reflect.Type, tag TagValue) (reflect.Value, error) {
+	return s.resolveInstance(&call{ctx: ctx}, tpe, tag)
+}
+
+type scopeKey struct{}
+
+// WithScope returns a copy of the context carrying the scope, e.g. a request scope
+func WithScope(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFrom returns the scope carried by the context (see WithScope), nil if there is none
+func ScopeFrom(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+func (r *parallelResolver) context() context.Context {
+	return r.call.ctx
+}
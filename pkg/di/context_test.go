@@ -0,0 +1,122 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ctxKey struct{}
+
+var _ = Describe("Context", func() {
+	var sut *di.Scope
+	BeforeEach(func() {
+		sut = &di.Scope{}
+	})
+	It("should pass the context to factories", func() {
+		sut.MustRegister(func(ctx context.Context) ValueA { return ValueA(ctx.Value(ctxKey{}).(string)) })
+		sut.MustRegister(func(ctx context.Context, in InA) ValueB { return ValueB(in.A) + "b" })
+		instance := &struct {
+			B ValueB `inject:""`
+		}{}
+		ctx := context.WithValue(context.Background(), ctxKey{}, "a")
+		Expect(sut.WireContext(ctx, instance)).To(Succeed())
+		Expect(instance.B).To(BeEquivalentTo("ab"))
+	})
+	It("should pass the background context without WireContext", func() {
+		reg := sut.MustRegister(func(ctx context.Context) ValueA {
+			Expect(ctx).To(Equal(context.Background()))
+			return "a"
+		})
+		Expect(reg.Parameters).To(Equal([]reflect.Type{reflect.TypeOf((*context.Context)(nil)).Elem()}))
+		instance, _, err := reg.GetInstance()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(instance).To(BeEquivalentTo("a"))
+	})
+	It("should reject the context as other than first parameter", func() {
+		_, err := sut.Register(func(InA, context.Context) ValueB { return "" })
+		Expect(err).To(MatchError(ContainSubstring("leading context.Context and parameter objects")))
+	})
+	It("should report the component whose deadline has been exceeded", func() {
+		sut.MustRegister(func(ctx context.Context) (ValueA, error) {
+			select {
+			case <-ctx.Done():
+				return "", ctx.Err()
+			case <-time.After(time.Second):
+				return "a", nil
+			}
+		})
+		sut.MustRegister(func(InA) ValueB { return "b" })
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err := sut.ResolveContext(ctx, reflect.TypeOf(ValueB("")), di.TagValue{})
+		Expect(errors.Is(err, context.DeadlineExceeded)).To(BeTrue())
+		var canceled *di.CanceledError
+		Expect(errors.As(err, &canceled)).To(BeTrue())
+		Expect(canceled.Registration.Type).To(Equal(reflect.TypeOf(ValueA(""))))
+		Expect(err).To(MatchError(ContainSubstring("creation canceled: component di_test.ValueA")))
+	})
+	It("should not call factories once the context is done", func() {
+		called := false
+		sut.MustRegister(func() ValueA {
+			called = true
+			return "a"
+		})
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := sut.WireContext(ctx, &ComponentA1{})
+		Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+		Expect(called).To(BeFalse())
+		Expect(sut.Wire(&ComponentA1{})).To(Succeed())
+		Expect(called).To(BeTrue())
+	})
+	It("should pass the context of each call to concurrent calls", func() {
+		valueOf := func(ctx context.Context) string {
+			// let the other call start, so that both calls are resolving concurrently
+			time.Sleep(20 * time.Millisecond)
+			return ctx.Value(ctxKey{}).(string)
+		}
+		sut.MustRegister(func(ctx context.Context) ValueA { return ValueA(valueOf(ctx)) })
+		sut.MustRegister(func(ctx context.Context) ValueB { return ValueB(valueOf(ctx)) })
+		a := &struct {
+			A ValueA `inject:""`
+		}{}
+		b := &struct {
+			B ValueB `inject:""`
+		}{}
+		done := make(chan error)
+		go func() { done <- sut.WireContext(context.WithValue(context.Background(), ctxKey{}, "a"), a) }()
+		go func() { done <- sut.WireContext(context.WithValue(context.Background(), ctxKey{}, "b"), b) }()
+		Expect(<-done).To(Succeed())
+		Expect(<-done).To(Succeed())
+		Expect(a.A).To(BeEquivalentTo("a"))
+		Expect(b.B).To(BeEquivalentTo("b"))
+	})
+	It("should pass the context to invoked functions", func() {
+		sut.MustRegister(ValueA("a"))
+		results, err := sut.Invoke(func(ctx context.Context, a ValueA) bool { return ctx != nil && a == "a" })
+		Expect(err).NotTo(HaveOccurred())
+		Expect(results[0].Bool()).To(BeTrue())
+	})
+	It("should pass the context of InstantiateAll", func() {
+		ctx := context.WithValue(context.Background(), ctxKey{}, "a")
+		sut.MustRegister(func(ctx context.Context) ValueA { return ValueA(ctx.Value(ctxKey{}).(string)) }).Eager()
+		sut.MustRegister(func(ctx context.Context, in InA) ValueB { return ValueB(ctx.Value(ctxKey{}).(string)) }).Eager()
+		sut.Apply(di.WithParallelism(2))
+		Expect(sut.InstantiateAll(ctx)).To(Succeed())
+		instance := &struct {
+			B ValueB `inject:""`
+		}{}
+		sut.MustWire(instance)
+		Expect(instance.B).To(BeEquivalentTo("a"))
+	})
+	It("should carry the scope in the context", func() {
+		Expect(di.ScopeFrom(context.Background())).To(BeNil())
+		Expect(di.ScopeFrom(di.WithScope(context.Background(), sut))).To(BeIdenticalTo(sut))
+	})
+})
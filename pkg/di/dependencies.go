@@ -0,0 +1,115 @@
+package di
+
+import (
+	"reflect"
+)
+
+// dependency is a type resolved for a registration, either by its factory or while wiring its instance
+type dependency struct {
+	tpe reflect.Type
+	tag TagValue
+}
+
+// dependenciesOf returns the dependencies of the registration known before its creation: the parameters of its
+// factory and the injections of its type, if it is a struct ptr. The injections of the concrete type of an instance
+// registered by an interface type are unknown.
+func dependenciesOf(registration *Registration, unexported, methods bool) []dependency {
+	result := parameterDependencies(registration.Parameters)
+	if registration.Type.Kind() != reflect.Ptr || registration.Type.Elem().Kind() != reflect.Struct {
+		return result
+	}
+	injectable, err := injectableFrom(registration.Type, unexported, methods)
+	if err != nil {
+		return result
+	}
+	for _, injection := range injectable.Injections {
+		result = append(result, dependency{tpe: injection.Type, tag: TagValueFrom(injection.Tag.Get(TagKey))})
+	}
+	for _, method := range injectable.Methods {
+		// the receiver is the first parameter of the method type
+		result = append(result, parameterDependencies(parametersOf(method.Type, 1))...)
+	}
+	return result
+}
+
+// parameterDependencies returns the dependencies for parameters of a function, see resolveArguments
+func parameterDependencies(params []reflect.Type) []dependency {
+	var result []dependency
+	for _, param := range params {
+		if param == contextType {
+			continue
+		}
+		if !IsParameterObject(param) {
+			result = append(result, dependency{tpe: param, tag: TagValue{Required: true}})
+			continue
+		}
+		injectable, err := InjectableFrom(param)
+		if err != nil {
+			continue
+		}
+		for _, injection := range injectable.Injections {
+			result = append(result, dependency{tpe: injection.Type, tag: TagValueFrom(injection.Tag.Get(TagKey))})
+		}
+	}
+	return result
+}
+
+// dependencyRegistrations returns the registrations of this scope chosen for the dependencies of the registration as
+// the resolution would choose them, i.e. the candidates with the highest priority or all candidates for slices and
+// arrays, including the owner of a result (see Out). The registrations of the parents are created by the parents.
+func (s *Scope) dependencyRegistrations(registration *Registration) Registrations {
+	var result Registrations
+	if registration.owner != nil {
+		result = append(result, registration.owner)
+	}
+	for _, dep := range dependenciesOf(registration, s.InjectUnexported, s.InjectMethods) {
+		tpe, all := dep.tpe, false
+		if kind := tpe.Kind(); kind == reflect.Array || kind == reflect.Slice {
+			tpe, all = tpe.Elem(), true
+		}
+		tpe, _ = injectedTypeOf(tpe)
+		candidates := s.lookupVisibleCandidates(tpe, dep.tag, registration.module).ByPriority()
+		if !all && len(candidates) > 0 {
+			candidates = candidates.FilterPriority(candidates[0].Priority)
+		}
+		for _, candidate := range candidates {
+			if candidate != registration && s.registrations.contains(candidate) && !result.contains(candidate) {
+				result = append(result, candidate)
+			}
+		}
+	}
+	return result
+}
+
+// dependencyGraph returns the registrations and their transitive dependencies in dependency order, i.e. every
+// registration follows the registrations it depends on, and the dependencies of each of them. Otherwise the order of
+// the registrations is kept. Dependencies closing a cycle are omitted, cycles are left to be detected on creation.
+func (s *Scope) dependencyGraph(registrations Registrations) (Registrations, map[*Registration]Registrations) {
+	const (
+		visiting = iota + 1
+		visited
+	)
+	order := make(Registrations, 0, len(registrations))
+	dependencies := map[*Registration]Registrations{}
+	states := map[*Registration]int{}
+	var visit func(reg *Registration)
+	visit = func(reg *Registration) {
+		states[reg] = visiting
+		for _, dep := range s.dependencyRegistrations(reg) {
+			if states[dep] == 0 {
+				visit(dep)
+			}
+			if states[dep] == visited {
+				dependencies[reg] = append(dependencies[reg], dep)
+			}
+		}
+		states[reg] = visited
+		order = append(order, reg)
+	}
+	for _, reg := range registrations {
+		if states[reg] == 0 {
+			visit(reg)
+		}
+	}
+	return order, dependencies
+}
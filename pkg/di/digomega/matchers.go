@@ -0,0 +1,207 @@
+package digomega
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/ditest"
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+	"github.com/pkg/errors"
+)
+
+// BeResolvable succeeds if the actual scope (*di.Scope or *ditest.Scope) resolves the type using the optional inject
+// tag value, e.g. "qualifier=a". The candidates are chosen without side effects (see di.Scope#Explain), i.e. no instances
+// are created and no registrations are marked as selected. Use WireSuccessfully to check the creation of the instances.
+func BeResolvable(tpe reflect.Type, tag ...string) types.GomegaMatcher {
+	tagValue := di.TagValueFrom("")
+	if len(tag) > 0 {
+		tagValue = di.TagValueFrom(tag[0])
+	}
+	return &resolvableMatcher{tpe: tpe, tag: tagValue}
+}
+
+// HaveRegistration succeeds if the actual scope (*di.Scope or *ditest.Scope) or its parents have a registration
+// coercible to the type with the qualifier
+func HaveRegistration(tpe reflect.Type, qualifier string) types.GomegaMatcher {
+	return &registrationMatcher{tpe: tpe, qualifier: qualifier}
+}
+
+// WireSuccessfully succeeds if the actual scope (*di.Scope or *ditest.Scope) wires the target without error
+func WireSuccessfully(target interface{}) types.GomegaMatcher {
+	return &wireMatcher{target: target}
+}
+
+// HaveBeenInjectedWith succeeds if the field of the actual struct (ptr) is deeply equal to the value
+func HaveBeenInjectedWith(field string, value interface{}) types.GomegaMatcher {
+	return &injectedMatcher{field: field, value: value}
+}
+
+var (
+	scopeType            = reflect.TypeOf(&di.Scope{})
+	instanceResolverType = reflect.TypeOf((*di.InstanceResolver)(nil)).Elem()
+)
+
+type resolvableMatcher struct {
+	tpe        reflect.Type
+	tag        di.TagValue
+	err        error
+	candidates di.Registrations
+}
+
+func (m *resolvableMatcher) Match(actual interface{}) (bool, error) {
+	scope, err := scopeOf(actual)
+	if err != nil {
+		return false, err
+	}
+	candidateTpe := m.tpe
+	if candidateTpe.Kind() == reflect.Slice || candidateTpe.Kind() == reflect.Array {
+		candidateTpe = candidateTpe.Elem()
+	}
+	m.candidates = scope.Candidates(candidateTpe, m.tag)
+	m.err = resolvable(scope.Explain(m.tpe, m.tag))
+	return m.err == nil, nil
+}
+
+// resolvable checks the registrations chosen by the explanation like di.Scope#ResolveInstance
+func resolvable(explanation di.Explanation) error {
+	var ambiguous di.Registrations
+	for _, explained := range explanation.Registrations {
+		if explained.Verdict == di.VerdictAmbiguous {
+			ambiguous = append(ambiguous, explained.Registration)
+		}
+	}
+	tpe := explanation.Type
+	switch {
+	case len(ambiguous) > 0:
+		return errors.Errorf("multiple candidates with priority %v for %v:\n\t%v", ambiguous[0].Priority, tpe, ambiguous)
+	case len(explanation.Chosen()) > 0 || !explanation.Tag.Required:
+		return nil
+	case tpe == scopeType || tpe == instanceResolverType:
+		// the scope resolves itself, if not registered otherwise
+		return nil
+	default:
+		return errors.Errorf("no candidate found for: %v", tpe)
+	}
+}
+
+func (m *resolvableMatcher) FailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope to resolve %v, but got error:\n\t%v\n%v", m.tpe, m.err, candidates(m.candidates))
+}
+
+func (m *resolvableMatcher) NegatedFailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope not to resolve %v\n%v", m.tpe, candidates(m.candidates))
+}
+
+type registrationMatcher struct {
+	tpe           reflect.Type
+	qualifier     string
+	registrations di.Registrations
+}
+
+func (m *registrationMatcher) Match(actual interface{}) (bool, error) {
+	scope, err := scopeOf(actual)
+	if err != nil {
+		return false, err
+	}
+	m.registrations = scope.Registrations()
+	return len(scope.Candidates(m.tpe, di.TagValue{Qualifier: m.qualifier})) > 0, nil
+}
+
+func (m *registrationMatcher) FailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope to have registration for %v\n%v", m.descriptor(), registrations(m.registrations))
+}
+
+func (m *registrationMatcher) NegatedFailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope not to have registration for %v\n%v", m.descriptor(), registrations(m.registrations))
+}
+
+func (m *registrationMatcher) descriptor() string {
+	if len(m.qualifier) > 0 {
+		return fmt.Sprintf("%v with qualifier %v", m.tpe, m.qualifier)
+	}
+	return m.tpe.String()
+}
+
+type wireMatcher struct {
+	target        interface{}
+	err           error
+	registrations di.Registrations
+}
+
+func (m *wireMatcher) Match(actual interface{}) (bool, error) {
+	scope, err := scopeOf(actual)
+	if err != nil {
+		return false, err
+	}
+	m.registrations = scope.Registrations()
+	m.err = scope.Wire(m.target)
+	return m.err == nil, nil
+}
+
+func (m *wireMatcher) FailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope to wire %T, but got error:\n\t%v\n%v", m.target, m.err, registrations(m.registrations))
+}
+
+func (m *wireMatcher) NegatedFailureMessage(interface{}) string {
+	return fmt.Sprintf("Expected scope not to wire %T\n%v", m.target, registrations(m.registrations))
+}
+
+type injectedMatcher struct {
+	field  string
+	value  interface{}
+	actual interface{}
+}
+
+func (m *injectedMatcher) Match(actual interface{}) (bool, error) {
+	val := reflect.ValueOf(actual)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return false, errors.Errorf("HaveBeenInjectedWith expects a struct (ptr), but got: %T", actual)
+	}
+	fld := val.FieldByName(m.field)
+	if !fld.IsValid() {
+		return false, errors.Errorf("field '%v' is not valid in: %T", m.field, actual)
+	}
+	if !fld.CanInterface() {
+		return false, errors.Errorf("field '%v' is not exported in: %T", m.field, actual)
+	}
+	m.actual = fld.Interface()
+	return reflect.DeepEqual(m.actual, m.value), nil
+}
+
+func (m *injectedMatcher) FailureMessage(interface{}) string {
+	return format.Message(m.actual, fmt.Sprintf("field %v to have been injected with", m.field), m.value)
+}
+
+func (m *injectedMatcher) NegatedFailureMessage(interface{}) string {
+	return format.Message(m.actual, fmt.Sprintf("field %v not to have been injected with", m.field), m.value)
+}
+
+func scopeOf(actual interface{}) (*di.Scope, error) {
+	switch scope := actual.(type) {
+	case *di.Scope:
+		return scope, nil
+	case *ditest.Scope:
+		return scope.Scope, nil
+	default:
+		return nil, errors.Errorf("expected *di.Scope or *ditest.Scope, but got: %T", actual)
+	}
+}
+
+func candidates(regs di.Registrations) string {
+	if len(regs) == 0 {
+		return "no candidates"
+	}
+	return fmt.Sprintf("candidates:\n\t%v", regs)
+}
+
+func registrations(regs di.Registrations) string {
+	if len(regs) == 0 {
+		return "no registrations"
+	}
+	return fmt.Sprintf("registrations:\n\t%v", regs)
+}
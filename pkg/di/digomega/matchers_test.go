@@ -0,0 +1,112 @@
+package digomega_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/dbsystel/golang-runtime-di/pkg/di/digomega"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/ditest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Matchers", func() {
+	valueType := reflect.TypeOf(Value(""))
+	var scope *di.Scope
+	BeforeEach(func() {
+		scope = &di.Scope{}
+		scope.MustRegister(Value("a"))
+		scope.MustRegister(Value("b")).WithQualifier("b")
+	})
+	Context("BeResolvable()", func() {
+		It("should match resolvable types", func() {
+			Expect(scope).To(BeResolvable(valueType))
+			Expect(scope).To(BeResolvable(valueType, "qualifier=b"))
+			Expect(scope).To(BeResolvable(reflect.TypeOf([]Value{}), "qualifier=*"))
+		})
+		It("should not match missing required types", func() {
+			Expect(scope).NotTo(BeResolvable(valueType, "qualifier=c"))
+			Expect(scope).To(BeResolvable(valueType, "qualifier=c,optional"))
+			Expect(scope).To(BeResolvable(reflect.TypeOf(&di.Scope{})))
+		})
+		It("should not create instances", func() {
+			var calls int
+			scope = &di.Scope{}
+			scope.MustRegister(func() Value { calls++; return "a" })
+			Expect(scope).To(BeResolvable(valueType))
+			Expect(calls).To(BeZero())
+			Expect(scope.Unused()).To(HaveLen(1))
+			Expect(scope.Stats()[0].InjectedInto).To(BeEmpty())
+		})
+		It("should print the candidates on failure", func() {
+			scope.MustRegister(Value("c"))
+			matcher := BeResolvable(valueType)
+			Expect(matcher.Match(scope)).To(BeFalse())
+			Expect(matcher.FailureMessage(scope)).To(And(
+				ContainSubstring("multiple candidates"),
+				ContainSubstring("candidates:\n\tcomponent digomega_test.Value"),
+			))
+			Expect(matcher.NegatedFailureMessage(scope)).To(ContainSubstring("not to resolve"))
+		})
+		It("should error on invalid actual", func() {
+			_, err := BeResolvable(valueType).Match("meh")
+			Expect(err).To(MatchError(ContainSubstring("expected *di.Scope or *ditest.Scope")))
+		})
+	})
+	Context("HaveRegistration()", func() {
+		It("should match registrations", func() {
+			Expect(scope).To(HaveRegistration(valueType, "b"))
+			Expect(scope).NotTo(HaveRegistration(valueType, "c"))
+		})
+		It("should print the registrations on failure", func() {
+			matcher := HaveRegistration(valueType, "c")
+			Expect(matcher.Match(scope)).To(BeFalse())
+			Expect(matcher.FailureMessage(scope)).To(And(
+				ContainSubstring("digomega_test.Value with qualifier c"),
+				ContainSubstring("registrations:\n\tcomponent digomega_test.Value"),
+			))
+			Expect(matcher.NegatedFailureMessage(&di.Scope{})).To(ContainSubstring("not to have"))
+		})
+	})
+	Context("WireSuccessfully()", func() {
+		It("should match wired targets", func() {
+			Expect(scope).To(WireSuccessfully(&Consumer{}))
+		})
+		It("should match test scopes", func() {
+			testScope := ditest.NewScope(GinkgoT(), scope)
+			defer testScope.Close()
+			Expect(testScope).To(WireSuccessfully(&Consumer{}))
+		})
+		It("should print the registrations on failure", func() {
+			matcher := WireSuccessfully(&Consumer{})
+			Expect(matcher.Match(&di.Scope{})).To(BeFalse())
+			Expect(matcher.FailureMessage(nil)).To(And(
+				ContainSubstring("no candidate found"),
+				ContainSubstring("no registrations"),
+			))
+			Expect(matcher.NegatedFailureMessage(nil)).To(ContainSubstring("not to wire"))
+		})
+	})
+	Context("HaveBeenInjectedWith()", func() {
+		It("should match injected fields", func() {
+			consumer := &Consumer{}
+			scope.MustWire(consumer)
+			Expect(consumer).To(HaveBeenInjectedWith("Value", Value("a")))
+			Expect(consumer).NotTo(HaveBeenInjectedWith("Value", Value("b")))
+		})
+		It("should print the field on failure", func() {
+			matcher := HaveBeenInjectedWith("Value", Value("b"))
+			Expect(matcher.Match(&Consumer{})).To(BeFalse())
+			Expect(matcher.FailureMessage(nil)).To(ContainSubstring("field Value to have been injected with"))
+			Expect(matcher.NegatedFailureMessage(nil)).To(ContainSubstring("field Value not to have been injected with"))
+		})
+		It("should error on invalid fields", func() {
+			_, err := HaveBeenInjectedWith("Meh", nil).Match(&Consumer{})
+			Expect(err).To(MatchError(ContainSubstring("field 'Meh' is not valid")))
+			_, err = HaveBeenInjectedWith("other", nil).Match(&Consumer{})
+			Expect(err).To(MatchError(ContainSubstring("field 'other' is not exported")))
+			_, err = HaveBeenInjectedWith("Value", nil).Match("meh")
+			Expect(err).To(MatchError(ContainSubstring("expects a struct")))
+		})
+	})
+})
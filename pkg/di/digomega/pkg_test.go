@@ -0,0 +1,21 @@
+package digomega_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDIGomega(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "golang-runtime-di-digomega")
+}
+
+type Value string
+
+type Consumer struct {
+	Value Value  `inject:""`
+	other string // nolint: structcheck,unused
+}
@@ -0,0 +1,84 @@
+package dihttp
+
+import (
+	"net/http"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/pkg/errors"
+)
+
+// Middleware creates a child scope of the Scope for each request, carried by the request's context (see di.ScopeFrom).
+// The request itself is registered as *http.Request in the child scope, which is closed when the request finishes.
+type Middleware struct {
+	// Scope is the parent of the request scopes
+	Scope *di.Scope
+	// ScopeOptions are applied to each request scope
+	ScopeOptions []di.ScopeOption
+	// Values returns additional request scoped values or factories to be registered in the request scope, optional
+	Values func(r *http.Request) []interface{}
+	// ErrorHandler responds to requests whose scope could not be set up or wired, http.StatusInternalServerError by
+	// default
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+	// CloseErrorHandler handles errors of the cleanup functions when closing a request scope, ignored by default
+	CloseErrorHandler func(r *http.Request, err error)
+}
+
+// Wrap returns a handler serving the request by the next handler using a request scope
+func (m Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		scope := m.Scope.NewChild(m.ScopeOptions...)
+		r = r.WithContext(di.WithScope(r.Context(), scope))
+		defer m.close(scope, r)
+		if err := m.register(scope, r); err != nil {
+			m.handleError(w, r, err)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Handler returns a handler serving each request by a new handler wired using the request scope
+func (m Middleware) Handler(newHandler func() http.Handler) http.Handler {
+	return m.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler := newHandler()
+		if err := di.ScopeFrom(r.Context()).WireContext(r.Context(), handler); err != nil {
+			m.handleError(w, r, errors.Wrap(err, "could not wire handler"))
+			return
+		}
+		handler.ServeHTTP(w, r)
+	}))
+}
+
+// Handler returns a handler serving each request by a new handler wired using a request scope, see Middleware
+func Handler(scope *di.Scope, newHandler func() http.Handler) http.Handler {
+	return Middleware{Scope: scope}.Handler(newHandler)
+}
+
+func (m Middleware) register(scope *di.Scope, r *http.Request) error {
+	if _, err := scope.Register(r); err != nil {
+		return errors.Wrap(err, "could not register request")
+	}
+	if m.Values == nil {
+		return nil
+	}
+	for _, value := range m.Values(r) {
+		if _, err := scope.Register(value); err != nil {
+			return errors.Wrap(err, "could not register request scoped value")
+		}
+	}
+	return nil
+}
+
+func (m Middleware) close(scope *di.Scope, r *http.Request) {
+	if err := scope.Close(); err != nil && m.CloseErrorHandler != nil {
+		m.CloseErrorHandler(r, err)
+	}
+}
+
+func (m Middleware) handleError(w http.ResponseWriter, r *http.Request, err error) {
+	if m.ErrorHandler != nil {
+		m.ErrorHandler(w, r, err)
+		return
+	}
+	http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+}
@@ -0,0 +1,162 @@
+package dihttp_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/dihttp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Greeting string
+
+type User string
+
+type GreetingHandler struct {
+	Request  *http.Request `inject:""`
+	Greeting Greeting      `inject:""`
+	User     User          `inject:""`
+}
+
+func (h *GreetingHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = fmt.Fprintf(w, "%v %v from %v", h.Greeting, h.User, h.Request.URL.Path)
+}
+
+type Greeter struct {
+	Greeting Greeting `inject:""`
+}
+
+type GreeterHandler struct {
+	Greeter *Greeter `inject:""`
+	User    User     `inject:""`
+}
+
+func (h *GreeterHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = fmt.Fprintf(w, "%v %v", h.Greeter.Greeting, h.User)
+}
+
+type Root struct {
+	Request *http.Request `inject:"optional"`
+}
+
+type RootHandler struct {
+	Root    *Root         `inject:""`
+	Request *http.Request `inject:""`
+}
+
+func (h *RootHandler) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	_, _ = fmt.Fprintf(w, "%v %v", h.Root.Request != nil, h.Request.URL.Path)
+}
+
+var _ = Describe("Middleware", func() {
+	var scope *di.Scope
+	var closed []string
+	var sut dihttp.Middleware
+	BeforeEach(func() {
+		closed = nil
+		scope = &di.Scope{}
+		scope.MustRegister(Greeting("hello"))
+		sut = dihttp.Middleware{
+			Scope: scope,
+			Values: func(r *http.Request) []interface{} {
+				user := User(r.URL.Query().Get("user"))
+				return []interface{}{func() (User, func(), error) {
+					return user, func() { closed = append(closed, string(user)) }, nil
+				}}
+			},
+		}
+	})
+	serve := func(handler http.Handler, target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		return recorder
+	}
+	It("should wire a handler per request and close the request scope", func() {
+		handler := sut.Handler(func() http.Handler { return &GreetingHandler{} })
+		Expect(serve(handler, "/a?user=alice").Body.String()).To(Equal("hello alice from /a"))
+		Expect(serve(handler, "/b?user=bob").Body.String()).To(Equal("hello bob from /b"))
+		Expect(closed).To(Equal([]string{"alice", "bob"}))
+		Expect(scope.Registrations()).To(HaveLen(1))
+	})
+	It("should serve concurrent requests sharing a singleton of the scope created lazily", func() {
+		var created int32
+		scope.MustRegister(func() *Greeter {
+			atomic.AddInt32(&created, 1)
+			time.Sleep(10 * time.Millisecond)
+			return &Greeter{}
+		})
+		sut.Values = func(r *http.Request) []interface{} {
+			return []interface{}{User(r.URL.Query().Get("user"))}
+		}
+		handler := sut.Handler(func() http.Handler { return &GreeterHandler{} })
+		var wg sync.WaitGroup
+		recorders := make([]*httptest.ResponseRecorder, 20)
+		for i := range recorders {
+			wg.Add(1)
+			go func(i int) {
+				defer GinkgoRecover()
+				defer wg.Done()
+				recorders[i] = serve(handler, fmt.Sprintf("/?user=u%v", i))
+			}(i)
+		}
+		wg.Wait()
+		for i, recorder := range recorders {
+			Expect(recorder.Code).To(Equal(http.StatusOK), recorder.Body.String())
+			Expect(recorder.Body.String()).To(Equal(fmt.Sprintf("hello u%v", i)))
+		}
+		Expect(created).To(BeEquivalentTo(1))
+	})
+	It("should wire the singletons of the scope without the values of a request", func() {
+		scope.MustRegister(func() *Root { return &Root{} })
+		handler := sut.Handler(func() http.Handler { return &RootHandler{} })
+		Expect(serve(handler, "/one").Body.String()).To(Equal("false /one"))
+		Expect(serve(handler, "/two").Body.String()).To(Equal("false /two"))
+	})
+	It("should carry the request scope in the request context", func() {
+		var requestScope *di.Scope
+		handler := sut.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestScope = di.ScopeFrom(r.Context())
+			target := &GreetingHandler{}
+			requestScope.MustWire(target)
+			Expect(target.Request.Context()).To(Equal(r.Context()))
+		}))
+		Expect(serve(handler, "/").Code).To(Equal(http.StatusOK))
+		Expect(requestScope.Parent).To(BeIdenticalTo(scope))
+	})
+	It("should respond with an error if the handler cannot be wired", func() {
+		recorder := serve(dihttp.Handler(scope, func() http.Handler { return &GreetingHandler{} }), "/")
+		Expect(recorder.Code).To(Equal(http.StatusInternalServerError))
+	})
+	It("should use the error handlers", func() {
+		var handled, closeErr error
+		sut.Values = func(*http.Request) []interface{} {
+			return []interface{}{func() (User, func() error, error) {
+				return "u", func() error { return errors.New("close") }, nil
+			}}
+		}
+		sut.ErrorHandler = func(w http.ResponseWriter, _ *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusBadRequest)
+		}
+		sut.CloseErrorHandler = func(_ *http.Request, err error) { closeErr = err }
+		handler := sut.Handler(func() http.Handler {
+			return &struct {
+				GreetingHandler
+				Missing float64 `inject:""`
+			}{}
+		})
+		Expect(serve(handler, "/").Code).To(Equal(http.StatusBadRequest))
+		Expect(handled).To(MatchError(ContainSubstring("could not wire handler")))
+		Expect(closeErr).NotTo(HaveOccurred())
+		handler = sut.Handler(func() http.Handler { return &GreetingHandler{} })
+		Expect(serve(handler, "/").Code).To(Equal(http.StatusOK))
+		Expect(closeErr).To(MatchError(ContainSubstring("close")))
+	})
+})
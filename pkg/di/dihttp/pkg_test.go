@@ -0,0 +1,14 @@
+package dihttp_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDIHTTP(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "golang-runtime-di-dihttp")
+}
@@ -0,0 +1,25 @@
+module github.com/dbsystel/golang-runtime-di/pkg/di/diotel
+
+go 1.17
+
+require (
+	github.com/dbsystel/golang-runtime-di v0.0.0-00010101000000-000000000000
+	github.com/onsi/ginkgo/v2 v2.1.4
+	github.com/onsi/gomega v1.19.0
+	go.opentelemetry.io/otel v1.10.0
+	go.opentelemetry.io/otel/sdk v1.10.0
+	go.opentelemetry.io/otel/trace v1.10.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/net v0.0.0-20220531201128-c960675eff93 // indirect
+	golang.org/x/sys v0.0.0-20220520151302-bc2c85ada10a // indirect
+	golang.org/x/text v0.3.7 // indirect
+	gopkg.in/yaml.v2 v2.4.0 // indirect
+)
+
+// the root module has not been tagged yet, drop the replace once a release of the root module can be required
+replace github.com/dbsystel/golang-runtime-di => ../../..
@@ -0,0 +1,14 @@
+package diotel_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDIOtel(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "golang-runtime-di-diotel")
+}
@@ -0,0 +1,49 @@
+package diotel
+
+import (
+	"context"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// InstrumentationName is the name of the OpenTelemetry tracer created by NewTracer
+const InstrumentationName = "github.com/dbsystel/golang-runtime-di"
+
+var _ di.Tracer = Tracer{}
+
+// Tracer adapts an OpenTelemetry trace.Tracer to a di.Tracer
+type Tracer struct {
+	Tracer trace.Tracer
+}
+
+// NewTracer creates a Tracer using a tracer of the provider named InstrumentationName
+func NewTracer(provider trace.TracerProvider) Tracer {
+	return Tracer{Tracer: provider.Tracer(InstrumentationName)}
+}
+
+// Start implements di.Tracer
+func (t Tracer) Start(ctx context.Context, name string, attributes ...di.TraceAttribute) (context.Context, di.Span) {
+	attrs := make([]attribute.KeyValue, len(attributes))
+	for idx, attr := range attributes {
+		attrs[idx] = attribute.String(attr.Key, attr.Value)
+	}
+	ctx, span := t.Tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+	return ctx, Span{Span: span}
+}
+
+// Span adapts an OpenTelemetry trace.Span to a di.Span
+type Span struct {
+	Span trace.Span
+}
+
+// End implements di.Span, recording the error and setting the status to error if not nil
+func (s Span) End(err error) {
+	if err != nil {
+		s.Span.RecordError(err)
+		s.Span.SetStatus(codes.Error, err.Error())
+	}
+	s.Span.End()
+}
@@ -0,0 +1,51 @@
+package diotel_test
+
+import (
+	"errors"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/diotel"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type Dependency string
+
+type Component struct {
+	Dependency Dependency `inject:""`
+}
+
+var _ = Describe("Tracer", func() {
+	var recorder *tracetest.SpanRecorder
+	var scope *di.Scope
+	BeforeEach(func() {
+		recorder = tracetest.NewSpanRecorder()
+		provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		scope = di.NewScope(di.WithTracer(diotel.NewTracer(provider)))
+	})
+	It("should create nested spans", func() {
+		scope.MustRegister(Dependency("a"))
+		scope.MustWire(&Component{})
+		spans := recorder.Ended()
+		Expect(spans).To(HaveLen(2))
+		factory, wire := spans[0], spans[1]
+		Expect(factory.Name()).To(Equal(di.SpanNameFactory))
+		Expect(factory.Attributes()).To(ContainElement(attribute.String(di.TraceAttributeType, "diotel_test.Dependency")))
+		Expect(factory.Parent().SpanID()).To(Equal(wire.SpanContext().SpanID()))
+		Expect(factory.InstrumentationScope().Name).To(Equal(diotel.InstrumentationName))
+		Expect(wire.Name()).To(Equal(di.SpanNameWire))
+		Expect(wire.Parent().IsValid()).To(BeFalse())
+	})
+	It("should record errors", func() {
+		scope.MustRegister(func() (Dependency, error) { return "", errors.New("fail") })
+		Expect(scope.Wire(&Component{})).NotTo(Succeed())
+		factory := recorder.Ended()[0]
+		Expect(factory.Status().Code).To(Equal(codes.Error))
+		Expect(factory.Status().Description).To(ContainSubstring("fail"))
+		Expect(factory.Events()).To(HaveLen(1))
+	})
+})
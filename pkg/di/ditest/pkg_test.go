@@ -0,0 +1,53 @@
+package ditest_test
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDITest(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "golang-runtime-di-ditest")
+}
+
+type Greeter interface {
+	Greet() string
+}
+
+type RealGreeter struct{}
+
+func (g *RealGreeter) Greet() string { return "hello" }
+
+type FakeGreeter struct{}
+
+func (g *FakeGreeter) Greet() string { return "fake" }
+
+type Consumer struct {
+	Greeter Greeter `inject:""`
+}
+
+// testTB records the calls of the test scope
+type testTB struct {
+	errors   []string
+	cleanups []func()
+}
+
+func (t *testTB) Helper() {}
+
+func (t *testTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *testTB) Cleanup(fn func()) {
+	t.cleanups = append(t.cleanups, fn)
+}
+
+func (t *testTB) finish() {
+	for idx := len(t.cleanups) - 1; idx >= 0; idx-- {
+		t.cleanups[idx]()
+	}
+}
@@ -0,0 +1,134 @@
+package ditest
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+)
+
+// TB is the subset of testing.TB used by the test scope
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+	Cleanup(func())
+}
+
+// Override is a fake registered for a type and qualifier in a test scope
+type Override struct {
+	// Type is the overridden type
+	Type reflect.Type
+	// Qualifier is the overridden qualifier
+	Qualifier string
+	// Fake is the fake (or mock) replacing the components
+	Fake interface{}
+	// Registration is the registration of the fake
+	Registration *di.Registration
+	// Used denotes if the fake has been resolved
+	Used bool
+}
+
+// String returns a descriptor for the Override
+func (o *Override) String() string {
+	name := o.Type.String()
+	if len(o.Qualifier) > 0 {
+		name = fmt.Sprintf("%v(%v)", name, o.Qualifier)
+	}
+	return fmt.Sprintf("override %v registered at: %v", name, o.Registration.Source)
+}
+
+// Scope is a test scope cloned from another scope, allowing to replace components with fakes
+type Scope struct {
+	*di.Scope
+	t         TB
+	overrides []*Override
+	closed    bool
+}
+
+// NewScope clones the scope (see di.Scope#Clone) for the test. Struct ptrs registered as instances are copied, so that
+// wiring them in the test scope does not inject fakes into the instances of the original scope.
+// When the test finishes, the test fails for each unused override and if the scope has not been closed.
+func NewScope(t TB, scope *di.Scope) *Scope {
+	t.Helper()
+	result := &Scope{Scope: scope.Clone(), t: t}
+	for _, reg := range result.Registrations() {
+		if reg.FactoryFn != nil && reg.ResolvingFactoryFn == nil {
+			reg.FactoryFn = copying(reg.FactoryFn)
+		}
+	}
+	t.Cleanup(result.verify)
+	return result
+}
+
+// copying returns a factory returning a shallow copy of the struct ptrs returned by the factory
+func copying(factory func() (interface{}, error)) func() (interface{}, error) {
+	return func() (interface{}, error) {
+		instance, err := factory()
+		value := reflect.ValueOf(instance)
+		if err != nil || value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+			return instance, err
+		}
+		result := reflect.New(value.Elem().Type())
+		result.Elem().Set(value.Elem())
+		return result.Interface(), nil
+	}
+}
+
+// Fake overrides the components of the scope for the type and qualifier with the fake (see di.Scope#Override),
+// failing the test if the fake is not coercible to the type
+func (s *Scope) Fake(tpe reflect.Type, qualifier string, fake interface{}) *Scope {
+	s.t.Helper()
+	if fake != nil && !coercible(tpe, reflect.TypeOf(fake)) {
+		s.t.Errorf("fake '%T' cannot be coerced to: %v", fake, tpe)
+		return s
+	}
+	override := &Override{Type: tpe, Qualifier: qualifier, Fake: fake}
+	factory := reflect.MakeFunc(reflect.FuncOf(nil, []reflect.Type{tpe}, false), func([]reflect.Value) []reflect.Value {
+		override.Used = true
+		if fake == nil {
+			return []reflect.Value{reflect.Zero(tpe)}
+		}
+		return []reflect.Value{reflect.ValueOf(fake)}
+	})
+	registration, err := s.Scope.Override(tpe, qualifier, factory.Interface())
+	if err != nil {
+		s.t.Errorf("could not override %v: %v", tpe, err)
+		return s
+	}
+	_, file, line, _ := runtime.Caller(1)
+	registration.Source = fmt.Sprintf("%v:%v", file, line)
+	override.Registration = registration
+	s.overrides = append(s.overrides, override)
+	return s
+}
+
+// Overrides returns the overrides of the scope
+func (s *Scope) Overrides() []*Override {
+	return s.overrides
+}
+
+// Close closes the underlying scope
+func (s *Scope) Close() error {
+	s.closed = true
+	return s.Scope.Close()
+}
+
+func (s *Scope) verify() {
+	s.t.Helper()
+	for _, override := range s.overrides {
+		if !override.Used {
+			s.t.Errorf("unused %v", override)
+		}
+	}
+	if !s.closed {
+		s.t.Errorf("scope leaked: Close() has not been called")
+	}
+}
+
+func coercible(tgt reflect.Type, src reflect.Type) bool {
+	if tgt.Kind() == reflect.Interface {
+		return src.Implements(tgt)
+	}
+	return src.AssignableTo(tgt)
+}
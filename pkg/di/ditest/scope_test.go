@@ -0,0 +1,87 @@
+package ditest_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/ditest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Scope", func() {
+	greeterType := reflect.TypeOf((*Greeter)(nil)).Elem()
+	var prod *di.Scope
+	var t *testTB
+	var sut *ditest.Scope
+	BeforeEach(func() {
+		prod = &di.Scope{}
+		prod.MustRegister(&RealGreeter{})
+		t = &testTB{}
+		sut = ditest.NewScope(t, prod)
+	})
+	It("should use the registrations of the scope", func() {
+		instance := &Consumer{}
+		sut.MustWire(instance)
+		Expect(sut.Close()).To(Succeed())
+		t.finish()
+		Expect(instance.Greeter.Greet()).To(Equal("hello"))
+		Expect(t.errors).To(BeEmpty())
+	})
+	It("should use the fake", func() {
+		sut.Fake(greeterType, "", &FakeGreeter{})
+		instance := &Consumer{}
+		sut.MustWire(instance)
+		Expect(sut.Close()).To(Succeed())
+		t.finish()
+		Expect(instance.Greeter.Greet()).To(Equal("fake"))
+		Expect(sut.Overrides()).To(HaveLen(1))
+		Expect(sut.Overrides()[0].Used).To(BeTrue())
+		Expect(sut.Overrides()[0].Registration.Source).To(ContainSubstring("scope_test.go:"))
+		Expect(t.errors).To(BeEmpty())
+	})
+	It("should not affect the original scope", func() {
+		sut.Fake(greeterType, "", &FakeGreeter{})
+		instance := &Consumer{}
+		prod.MustWire(instance)
+		Expect(instance.Greeter.Greet()).To(Equal("hello"))
+	})
+	It("should not wire the instances registered in the original scope", func() {
+		registered := &Consumer{}
+		prod.MustRegister(registered)
+		sut = ditest.NewScope(t, prod)
+		sut.Fake(greeterType, "", &FakeGreeter{})
+		instance := &struct {
+			Consumer *Consumer `inject:""`
+		}{}
+		sut.MustWire(instance)
+		Expect(instance.Consumer.Greeter.Greet()).To(Equal("fake"))
+		Expect(registered.Greeter).To(BeNil())
+		prod.MustWire(instance)
+		Expect(instance.Consumer).To(BeIdenticalTo(registered))
+		Expect(registered.Greeter.Greet()).To(Equal("hello"))
+	})
+	It("should keep the components not resolved for the type", func() {
+		prod.MustRegister("unrelated")
+		sut = ditest.NewScope(t, prod)
+		sut.Fake(reflect.TypeOf((*interface{})(nil)).Elem(), "", "fake")
+		instance := &Consumer{}
+		sut.MustWire(instance)
+		Expect(instance.Greeter.Greet()).To(Equal("hello"))
+	})
+	It("should fail on unused overrides", func() {
+		sut.Fake(greeterType, "meh", nil)
+		Expect(sut.Close()).To(Succeed())
+		t.finish()
+		Expect(t.errors).To(ConsistOf(ContainSubstring("unused override ditest_test.Greeter(meh)")))
+	})
+	It("should fail on leaked scope", func() {
+		t.finish()
+		Expect(t.errors).To(ConsistOf(ContainSubstring("scope leaked")))
+	})
+	It("should fail on fakes not coercible", func() {
+		sut.Fake(greeterType, "", "meh")
+		Expect(t.errors).To(ConsistOf(ContainSubstring("cannot be coerced")))
+		Expect(sut.Overrides()).To(BeEmpty())
+	})
+})
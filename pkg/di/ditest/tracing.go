@@ -0,0 +1,110 @@
+package ditest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+)
+
+var _ di.Tracer = &SpanRecorder{}
+
+// RecordedSpan is a span recorded by a SpanRecorder
+type RecordedSpan struct {
+	// Name is the name of the span
+	Name string
+	// Attributes are the attributes the span has been started with
+	Attributes []di.TraceAttribute
+	// Parent is the parent span, nil for root spans
+	Parent *RecordedSpan
+	// Children are the child spans in the order they have been started
+	Children []*RecordedSpan
+	// Ended denotes if the span has been ended
+	Ended bool
+	// Err is the error the span has been ended with
+	Err      error
+	recorder *SpanRecorder
+}
+
+// Attribute returns the value of the attribute with the key
+func (s *RecordedSpan) Attribute(key string) (string, bool) {
+	for _, attribute := range s.Attributes {
+		if attribute.Key == key {
+			return attribute.Value, true
+		}
+	}
+	return "", false
+}
+
+// End implements di.Span
+func (s *RecordedSpan) End(err error) {
+	s.recorder.mutex.Lock()
+	defer s.recorder.mutex.Unlock()
+	s.Ended, s.Err = true, err
+}
+
+// String returns the span and its children as indented tree, using the type attribute as description
+func (s *RecordedSpan) String() string {
+	builder := &strings.Builder{}
+	s.write(builder, 0)
+	return builder.String()
+}
+
+func (s *RecordedSpan) write(builder *strings.Builder, depth int) {
+	tpe, _ := s.Attribute(di.TraceAttributeType)
+	_, _ = fmt.Fprintf(builder, "%v%v %v\n", strings.Repeat("  ", depth), s.Name, tpe)
+	for _, child := range s.Children {
+		child.write(builder, depth+1)
+	}
+}
+
+type spanKey struct{}
+
+// SpanRecorder is an in-memory di.Tracer for tests, recording all started spans
+type SpanRecorder struct {
+	mutex sync.Mutex
+	spans []*RecordedSpan
+}
+
+// Start implements di.Tracer
+func (r *SpanRecorder) Start(ctx context.Context, name string,
+	attributes ...di.TraceAttribute) (context.Context, di.Span) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	span := &RecordedSpan{Name: name, Attributes: attributes, recorder: r}
+	if parent, ok := ctx.Value(spanKey{}).(*RecordedSpan); ok && parent.recorder == r {
+		span.Parent = parent
+		parent.Children = append(parent.Children, span)
+	}
+	r.spans = append(r.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+// Spans returns all recorded spans in the order they have been started
+func (r *SpanRecorder) Spans() []*RecordedSpan {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append([]*RecordedSpan(nil), r.spans...)
+}
+
+// Roots returns the recorded spans without parent
+func (r *SpanRecorder) Roots() []*RecordedSpan {
+	var result []*RecordedSpan
+	for _, span := range r.Spans() {
+		if span.Parent == nil {
+			result = append(result, span)
+		}
+	}
+	return result
+}
+
+// String returns all recorded span trees
+func (r *SpanRecorder) String() string {
+	builder := &strings.Builder{}
+	for _, root := range r.Roots() {
+		root.write(builder, 0)
+	}
+	return builder.String()
+}
@@ -0,0 +1,57 @@
+package ditest_test
+
+import (
+	"errors"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	"github.com/dbsystel/golang-runtime-di/pkg/di/ditest"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type GreeterParams struct {
+	di.In
+	Name string `inject:""`
+}
+
+type Service struct {
+	Greeter Greeter `inject:""`
+}
+
+var _ = Describe("SpanRecorder", func() {
+	var recorder *ditest.SpanRecorder
+	var scope *di.Scope
+	BeforeEach(func() {
+		recorder = &ditest.SpanRecorder{}
+		scope = di.NewScope(di.WithTracer(recorder))
+	})
+	It("should record spans nested according to the dependency chain", func() {
+		scope.MustRegister("world")
+		scope.MustRegister(func(GreeterParams) Greeter { return &RealGreeter{} })
+		scope.MustRegister(func() *Service { return &Service{} })
+		scope.MustWire(&struct {
+			Service *Service `inject:""`
+		}{})
+		Expect(recorder.String()).To(Equal(`di.wire *struct { Service *ditest_test.Service "inject:\"\"" }
+  di.factory *ditest_test.Service
+  di.wire *ditest_test.Service
+    di.factory ditest_test.Greeter
+      di.factory string
+    di.wire ditest_test.Greeter
+`))
+		for _, span := range recorder.Spans() {
+			Expect(span.Ended).To(BeTrue())
+			Expect(span.Err).NotTo(HaveOccurred())
+		}
+		source, found := recorder.Spans()[1].Attribute(di.TraceAttributeSource)
+		Expect(found).To(BeTrue())
+		Expect(source).To(ContainSubstring("tracing_test.go:"))
+	})
+	It("should record the error", func() {
+		scope.MustRegister(func() (Greeter, error) { return nil, errors.New("fail") })
+		Expect(scope.Wire(&Consumer{})).NotTo(Succeed())
+		Expect(recorder.Roots()).To(HaveLen(1))
+		Expect(recorder.Roots()[0].Err).To(HaveOccurred())
+		Expect(recorder.Roots()[0].Children[0].Err).To(MatchError(ContainSubstring("fail")))
+	})
+})
@@ -0,0 +1,59 @@
+package di
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// Eager marks the registration to be instantiated by Scope#InstantiateAll returning the same ptr as in the receiver
+func (r *Registration) Eager() *Registration {
+	r.eager = true
+	return r
+}
+
+// IsEager denotes if the registration is instantiated by Scope#InstantiateAll
+func (r *Registration) IsEager() bool {
+	return r.eager
+}
+
+// InstantiateAll creates and wires the instances of all eager registrations of this scope up front, e.g. to fail fast
+// on startup instead of the first injection. The registrations are instantiated in dependency order, concurrently if
+// the Parallelism is set. The errors of all failed registrations are aggregated in dependency order. The
+// instantiation is aborted, once the context is done.
+func (s *Scope) InstantiateAll(ctx context.Context) error {
+	eager := s.registrations.filter(func(reg *Registration) bool { return reg.eager })
+	order, dependencies := s.dependencyGraph(eager)
+	var failed map[*Registration]error
+	var aborted error
+	if s.Parallelism > 1 {
+		failed, aborted = s.instantiateParallel(ctx, order, dependencies)
+	} else {
+		failed, aborted = s.instantiateSequential(ctx, order.filter(eager.contains))
+	}
+	var errs Errors
+	for _, reg := range order {
+		if err, found := failed[reg]; found && eager.contains(reg) {
+			errs = append(errs, errors.Wrapf(err, "could not instantiate: %v", reg))
+		}
+	}
+	if aborted != nil {
+		errs = append(errs, errors.Wrap(aborted, "instantiation aborted"))
+	}
+	return errs.errOrNil()
+}
+
+func (s *Scope) instantiateSequential(ctx context.Context,
+	registrations Registrations) (map[*Registration]error, error) {
+	failed := map[*Registration]error{}
+	c := &call{ctx: ctx}
+	for _, reg := range registrations {
+		if err := ctx.Err(); err != nil {
+			return failed, err
+		}
+		if _, err := s.wiredInstance(c, reg); err != nil {
+			failed[reg] = err
+		}
+	}
+	return failed, nil
+}
@@ -0,0 +1,66 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("InstantiateAll", func() {
+	var sut *di.Scope
+	var created []string
+	BeforeEach(func() {
+		sut = &di.Scope{}
+		created = nil
+	})
+	It("should mark registrations as eager", func() {
+		reg := sut.MustRegister(ValueA("a"))
+		Expect(reg.IsEager()).To(BeFalse())
+		Expect(reg.Eager()).To(BeIdenticalTo(reg))
+		Expect(reg.IsEager()).To(BeTrue())
+		Expect(reg.Clone().IsEager()).To(BeTrue())
+	})
+	It("should instantiate eager registrations in dependency order", func() {
+		sut.MustRegister(func() *ComponentB1 {
+			created = append(created, "b1")
+			return &ComponentB1{}
+		}).Eager()
+		sut.MustRegister(func(InA) *ComponentA1 {
+			created = append(created, "a1")
+			return &ComponentA1{}
+		})
+		sut.MustRegister(func() ValueA {
+			created = append(created, "a")
+			return "a"
+		}).Eager()
+		sut.MustRegister(func() ValueB {
+			created = append(created, "b")
+			return "b"
+		})
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(created).To(Equal([]string{"a", "b1", "a1", "b"}))
+		Expect(sut.Unused()).To(HaveLen(1))
+	})
+	It("should aggregate the errors", func() {
+		sut.MustRegister(func() (ValueA, error) { return "", errors.New("fail a") }).Eager()
+		sut.MustRegister(func() (ValueB, error) { return "", errors.New("fail b") }).Eager()
+		err := sut.InstantiateAll(context.Background())
+		Expect(err).To(BeAssignableToTypeOf(di.Errors{}))
+		Expect(err.(di.Errors)).To(HaveLen(2))
+		Expect(err).To(MatchError(MatchRegexp(`could not instantiate: component di_test.ValueA .*: fail a`)))
+		Expect(err).To(MatchError(ContainSubstring("fail b")))
+	})
+	It("should abort once the context is done", func() {
+		sut.MustRegister(func() ValueA {
+			created = append(created, "a")
+			return "a"
+		}).Eager()
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		Expect(sut.InstantiateAll(ctx)).To(MatchError(ContainSubstring("instantiation aborted: context canceled")))
+		Expect(created).To(BeEmpty())
+	})
+})
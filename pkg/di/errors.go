@@ -2,10 +2,33 @@ package di
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/pkg/errors"
 )
 
+// Errors aggregates multiple errors
+type Errors []error
+
+func (e Errors) Error() string {
+	var sb strings.Builder
+	for idx, err := range e {
+		if idx > 0 {
+			sb.WriteString("\n\t")
+		}
+		sb.WriteString(err.Error())
+	}
+	return sb.String()
+}
+
+// errOrNil returns nil, if there are no errors
+func (e Errors) errOrNil() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
 func errNoStructPtr(tpe reflect.Type) error {
 	return errors.Errorf("expected a struct pointer, but got: %v", tpe)
 }
@@ -17,3 +40,23 @@ func errNotCoercible(tgt reflect.Type, src reflect.Type) error {
 func errFieldNotExported(tpe reflect.Type, fld reflect.StructField) error {
 	return errors.Errorf("field not exported in type '%v': %v", tpe, fld.Name)
 }
+
+func errUnsupportedConfigField(tpe reflect.Type, fld reflect.StructField) error {
+	return errors.Errorf("unsupported config field type in '%v': %v (%v)", tpe, fld.Name, fld.Type)
+}
+
+func errMissingProperties(tpe reflect.Type, keys []string) error {
+	return errors.Errorf("missing required properties for '%v': %v", tpe, strings.Join(keys, ", "))
+}
+
+func errInvalidInjectMethod(tpe reflect.Type, method reflect.Method, reason string) error {
+	return errors.Errorf("invalid inject method in type '%v': %v %v", tpe, method.Name, reason)
+}
+
+func errFactoryPanicked(registration *Registration) error {
+	return errors.Errorf("factory panicked: %v", registration)
+}
+
+func errUnusedRegistrations(unused Registrations) error {
+	return errors.Errorf("unused registrations:\n\t%v", unused)
+}
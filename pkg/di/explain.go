@@ -0,0 +1,150 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Verdict denotes why a registration has been chosen or rejected for a resolution, see Scope#Explain
+type Verdict string
+
+const (
+	// VerdictChosen denotes the registration would be injected
+	VerdictChosen Verdict = "chosen"
+	// VerdictAmbiguous denotes the registration has the highest priority, but so have others
+	VerdictAmbiguous Verdict = "ambiguous"
+	// VerdictNotCoercible denotes the registration's type cannot be coerced to the resolved type
+	VerdictNotCoercible Verdict = "not coercible"
+	// VerdictQualifierMismatch denotes the registration's qualifier does not match
+	VerdictQualifierMismatch Verdict = "qualifier mismatch"
+	// VerdictLowerPriority denotes a candidate with a higher priority would be injected instead
+	VerdictLowerPriority Verdict = "lower priority"
+	// VerdictNotExported denotes the registration's module does not export its type, see Module
+	VerdictNotExported Verdict = "not exported"
+	// VerdictInactive denotes the registration's scope is not considered due to the LookupStrategy
+	VerdictInactive Verdict = "inactive"
+)
+
+// ExplainedRegistration is a registration with the verdict of a resolution
+type ExplainedRegistration struct {
+	// Registration is the explained registration
+	Registration *Registration
+	// Depth is the depth of the registration's scope, 0 for the explained scope, 1 for its parent and so on
+	Depth int
+	// Verdict denotes why the registration has been chosen or rejected
+	Verdict Verdict
+}
+
+// Explanation explains the resolution of a type in a Scope, see Scope#Explain
+type Explanation struct {
+	// Type is the resolved type
+	Type reflect.Type
+	// Tag is the tag value of the resolution
+	Tag TagValue
+	// Registrations are the registrations of the scope and its parents
+	Registrations []ExplainedRegistration
+}
+
+// Chosen returns the registrations which would be injected
+func (e Explanation) Chosen() Registrations {
+	var result Registrations
+	for _, explained := range e.Registrations {
+		if explained.Verdict == VerdictChosen {
+			result = append(result, explained.Registration)
+		}
+	}
+	return result
+}
+
+// String returns the explanation listing every registration with its verdict
+func (e Explanation) String() string {
+	var sb strings.Builder
+	identifier := e.Type.String()
+	if len(e.Tag.Qualifier) > 0 {
+		identifier += " with qualifier " + e.Tag.Qualifier
+	}
+	_, _ = fmt.Fprintf(&sb, "explanation for %v:", identifier)
+	if len(e.Registrations) == 0 {
+		sb.WriteString("\n\tno registrations")
+	}
+	for _, explained := range e.Registrations {
+		_, _ = fmt.Fprintf(&sb, "\n\t[scope %v] %v: %v", explained.Depth, explained.Registration, explained.Verdict)
+	}
+	return sb.String()
+}
+
+// ExplainedError is a resolution error including the explanation of the resolution, see Scope#ExplainErrors
+type ExplainedError struct {
+	Err         error
+	Explanation Explanation
+}
+
+func (e *ExplainedError) Error() string {
+	return e.Err.Error() + "\n" + e.Explanation.String()
+}
+
+func (e *ExplainedError) Cause() error {
+	return e.Err
+}
+
+func (e *ExplainedError) Unwrap() error {
+	return e.Err
+}
+
+// Explain explains the resolution of the type (like ResolveInstance) by listing every registration of this scope and
+// its parents together with the verdict why it has been chosen or rejected
+func (s *Scope) Explain(tpe reflect.Type, tag TagValue) Explanation {
+	return s.explain(tpe, tag, nil)
+}
+
+// explain explains the resolution of the type for a component of the module (nil outside of modules)
+func (s *Scope) explain(tpe reflect.Type, tag TagValue, module *Module) Explanation {
+	result := Explanation{Type: tpe, Tag: tag}
+	elemType, multiple := tpe, false
+	if kind := tpe.Kind(); kind == reflect.Array || kind == reflect.Slice {
+		elemType, multiple = tpe.Elem(), true
+	}
+	elemType, _ = injectedTypeOf(elemType)
+	candidates := s.lookupVisibleCandidates(elemType, tag, module).ByPriority()
+	var highest Registrations
+	if len(candidates) > 0 {
+		highest = candidates.FilterPriority(candidates[0].Priority)
+	}
+	for depth, scope := 0, s; scope != nil; depth, scope = depth+1, scope.Parent {
+		coercible := scope.registrations.FilterCoercible(elemType)
+		qualified := coercible
+		if !tag.IsAllQualifier() {
+			qualified = coercible.FilterQualifier(tag.Qualifier)
+		}
+		for _, reg := range scope.registrations {
+			verdict := VerdictChosen
+			switch {
+			case !coercible.contains(reg):
+				verdict = VerdictNotCoercible
+			case !qualified.contains(reg):
+				verdict = VerdictQualifierMismatch
+			case !reg.visibleTo(module, elemType):
+				verdict = VerdictNotExported
+			case !candidates.contains(reg):
+				verdict = VerdictInactive
+			case multiple:
+			case !highest.contains(reg):
+				verdict = VerdictLowerPriority
+			case len(highest) > 1:
+				verdict = VerdictAmbiguous
+			}
+			result.Registrations = append(result.Registrations,
+				ExplainedRegistration{Registration: reg, Depth: depth, Verdict: verdict})
+		}
+	}
+	return result
+}
+
+// explained adds the explanation of the resolution to the error, if enabled by ExplainErrors
+func (s *Scope) explained(c *call, err error, tpe reflect.Type, tag TagValue) error {
+	if !s.ExplainErrors {
+		return err
+	}
+	return &ExplainedError{Err: err, Explanation: s.explain(tpe, tag, c.consumerModule())}
+}
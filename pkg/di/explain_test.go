@@ -0,0 +1,82 @@
+package di_test
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Explain", func() {
+	valueAType := reflect.TypeOf(ValueA(""))
+	var parent, sut *di.Scope
+	var parentA, chosen *di.Registration
+	BeforeEach(func() {
+		parent = &di.Scope{}
+		parentA = parent.MustRegister(ValueA("parent"))
+		sut = parent.NewChild()
+		chosen = sut.MustRegister(ValueA("a"))
+		sut.MustRegister(ValueA("lower")).WithPriority(1)
+		sut.MustRegister(ValueA("qualified")).WithQualifier("q")
+		sut.MustRegister(ValueB("b"))
+	})
+	verdicts := func(explanation di.Explanation) []di.Verdict {
+		var result []di.Verdict
+		for _, explained := range explanation.Registrations {
+			result = append(result, explained.Verdict)
+		}
+		return result
+	}
+	It("should explain the verdict for every registration", func() {
+		explanation := sut.Explain(valueAType, di.TagValue{})
+		Expect(explanation.Registrations).To(HaveLen(5))
+		Expect(explanation.Registrations[0].Registration).To(Equal(chosen))
+		Expect(explanation.Registrations[4].Registration).To(Equal(parentA))
+		Expect(explanation.Registrations[4].Depth).To(Equal(1))
+		Expect(verdicts(explanation)).To(Equal([]di.Verdict{
+			di.VerdictChosen, di.VerdictLowerPriority, di.VerdictQualifierMismatch, di.VerdictNotCoercible,
+			di.VerdictInactive,
+		}))
+		Expect(explanation.Chosen()).To(Equal(di.Registrations{chosen}))
+	})
+	It("should explain ambiguous candidates", func() {
+		sut.Lookup = di.LookupMerge
+		explanation := sut.Explain(valueAType, di.TagValue{})
+		Expect(explanation.Registrations[0].Verdict).To(Equal(di.VerdictAmbiguous))
+		Expect(explanation.Registrations[4].Verdict).To(Equal(di.VerdictAmbiguous))
+		Expect(explanation.Chosen()).To(BeEmpty())
+	})
+	It("should choose all candidates for slices", func() {
+		explanation := sut.Explain(reflect.TypeOf([]ValueA{}), di.TagValue{Qualifier: di.AllQualifiers})
+		Expect(verdicts(explanation)).To(Equal([]di.Verdict{
+			di.VerdictChosen, di.VerdictChosen, di.VerdictChosen, di.VerdictNotCoercible, di.VerdictInactive,
+		}))
+	})
+	It("should print the explanation", func() {
+		Expect(sut.Explain(valueAType, di.TagValue{Qualifier: "x"}).String()).To(MatchRegexp(
+			`^explanation for di_test.ValueA with qualifier x:\n` +
+				`\t\[scope 0\] component di_test.ValueA with priority 0 registered at: \S+explain_test.go:\d+: ` +
+				`qualifier mismatch\n`))
+		Expect((&di.Scope{}).Explain(valueAType, di.TagValue{}).String()).To(Equal(
+			"explanation for di_test.ValueA:\n\tno registrations"))
+	})
+	It("should not explain errors by default", func() {
+		_, err := sut.ResolveInstance(valueAType, di.TagValue{Qualifier: "x", Required: true})
+		Expect(err).To(MatchError("no candidate found for: di_test.ValueA with qualifier x"))
+	})
+	It("should explain errors if enabled", func() {
+		sut.Apply(di.WithExplainErrors(true))
+		_, err := sut.ResolveInstance(valueAType, di.TagValue{Qualifier: "x", Required: true})
+		Expect(err).To(MatchError(ContainSubstring("explanation for di_test.ValueA with qualifier x:")))
+		var explained *di.ExplainedError
+		Expect(errors.As(err, &explained)).To(BeTrue())
+		Expect(explained.Explanation.Registrations).To(HaveLen(5))
+		sut.Lookup = di.LookupMerge
+		_, err = sut.ResolveInstance(valueAType, di.TagValue{Required: true})
+		Expect(err).To(MatchError(ContainSubstring("multiple candidates")))
+		Expect(err).To(MatchError(ContainSubstring(": ambiguous")))
+		Expect(sut.NewChild().ExplainErrors).To(BeTrue())
+	})
+})
@@ -15,6 +15,8 @@ type Injectable struct {
 	Type reflect.Type
 	// Injections denote the fields to be injected to
 	Injections []Injection
+	// Methods denote the methods to be called with resolved parameters after the fields are injected
+	Methods []MethodInjection
 }
 
 func (i Injectable) Apply(target reflect.Value, resolver InstanceResolver) error {
@@ -37,12 +39,32 @@ func (i Injectable) Apply(target reflect.Value, resolver InstanceResolver) error
 		if err = injection.Apply(target, resolved); err != nil {
 			return errors.Wrapf(err, "could not inject field: %v", injection.Name)
 		}
+		if observer, ok := resolver.(injectionObserver); ok {
+			observer.fieldInjected(target, injection, resolved)
+		}
+	}
+	// Call all inject methods
+	for _, method := range i.Methods {
+		if err := method.Apply(target, resolver); err != nil {
+			return errors.Wrapf(err, "could not inject using method: %v", method.Name)
+		}
 	}
 	return nil
 }
 
 // InjectableFrom creates an Injectable from a reflect.Type
 func InjectableFrom(tpe reflect.Type) (*Injectable, error) {
+	return injectableFrom(tpe, false, false)
+}
+
+// InjectableWithMethodsFrom creates an Injectable from a reflect.Type like InjectableFrom, including its inject methods
+// (see IsInjectMethod). Methods promoted from embedded fields are ignored, inject methods not returning nothing or an
+// error, or being variadic are reported as error.
+func InjectableWithMethodsFrom(tpe reflect.Type) (*Injectable, error) {
+	return injectableFrom(tpe, false, true)
+}
+
+func injectableFrom(tpe reflect.Type, unexported, methods bool) (*Injectable, error) {
 	// Unwrap pointers and interfaces
 	for tpe != nil && (tpe.Kind() == reflect.Ptr || tpe.Kind() == reflect.Interface) {
 		tpe = tpe.Elem()
@@ -57,11 +79,27 @@ func InjectableFrom(tpe reflect.Type) (*Injectable, error) {
 		structFld := tpe.Field(i)
 		// In case we have a tag for the field, get the Qualifier and create a new field injection
 		if _, hasTag := structFld.Tag.Lookup(TagKey); hasTag {
-			if !structFld.IsExported() {
+			if !structFld.IsExported() && !unexported {
 				return nil, errFieldNotExported(tpe, structFld)
 			}
 			result.Injections = append(result.Injections, Injection(structFld))
 		}
 	}
+	if !methods {
+		return &result, nil
+	}
+	// Scan the methods of the struct ptr, ignoring the methods of embedded fields
+	ptrTpe := reflect.PtrTo(tpe)
+	for i := 0; i < ptrTpe.NumMethod(); i++ {
+		method := ptrTpe.Method(i)
+		if !IsInjectMethod(method) || isPromoted(tpe, method) {
+			continue
+		}
+		methodInjection, err := MethodInjectionFrom(tpe, method)
+		if err != nil {
+			return nil, err
+		}
+		result.Methods = append(result.Methods, methodInjection)
+	}
 	return &result, nil
 }
@@ -49,6 +49,26 @@ var _ = Describe("InjectableFrom()", func() {
 		_, err := di.InjectableFrom(reflect.TypeOf((InterfaceA)(nil)))
 		Expect(err).To(MatchError(ContainSubstring("expected a struct pointer")))
 	})
+	It("should not return inject methods", func() {
+		res, err := di.InjectableFrom(reflect.TypeOf(&ComponentM{}))
+		Expect(res, err).To(BeAssignableToTypeOf(&di.Injectable{}))
+		Expect(res.Methods).To(BeEmpty())
+	})
+	It("should return inject methods if enabled", func() {
+		res, err := di.InjectableWithMethodsFrom(reflect.TypeOf(&ComponentM{}))
+		Expect(res, err).To(BeAssignableToTypeOf(&di.Injectable{}))
+		Expect(res.Methods).To(HaveLen(1))
+		Expect(res.Methods[0].Name).To(Equal("InjectA"))
+	})
+	It("should ignore the inject methods of embedded fields", func() {
+		res, err := di.InjectableWithMethodsFrom(reflect.TypeOf(&EmbeddingComponentM{}))
+		Expect(res, err).To(BeAssignableToTypeOf(&di.Injectable{}))
+		Expect(res.Methods).To(BeEmpty())
+	})
+	It("should return error on invalid inject methods", func() {
+		_, err := di.InjectableWithMethodsFrom(reflect.TypeOf(InvalidMethodComponent{}))
+		Expect(err).To(MatchError(ContainSubstring("InjectMeh should return nothing or error")))
+	})
 	It("should return error if field not exported", func() {
 		_, err := di.InjectableFrom(reflect.TypeOf(InvalidComponent{}))
 		Expect(err).To(MatchError(ContainSubstring("field not exported")))
@@ -73,6 +93,23 @@ var _ = Describe("Injectable", func() {
 			Expect(sut.Apply(reflect.ValueOf(&tgt), resolver)).NotTo(HaveOccurred())
 			Expect(tgt).To(Equal(ComponentA1{A: valueA, Other: "b"}))
 		})
+		It("should call inject methods", func() {
+			resolver.value = reflect.ValueOf(&ComponentA1{A: "a"})
+			methodTgt := &ComponentM{}
+			injectable, err := di.InjectableWithMethodsFrom(reflect.TypeOf(methodTgt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(injectable.Apply(reflect.ValueOf(methodTgt), resolver)).To(Succeed())
+			Expect(methodTgt.GetA()).To(Equal("a"))
+		})
+		It("should return error from inject methods", func() {
+			methodTgt := &ComponentM{err: errors.New("meh")}
+			resolver.value = reflect.ValueOf(&ComponentA1{A: "a"})
+			injectable, err := di.InjectableWithMethodsFrom(reflect.TypeOf(methodTgt))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(injectable.Apply(reflect.ValueOf(methodTgt), resolver)).To(MatchError(
+				ContainSubstring("could not inject using method: InjectA"),
+			))
+		})
 		It("should return error if not ptr", func() {
 			Expect(sut.Apply(reflect.ValueOf(tgt), resolver)).To(MatchError(
 				ContainSubstring("expected a struct pointer"),
@@ -0,0 +1,39 @@
+package di
+
+import "reflect"
+
+var (
+	scopeType            = reflect.TypeOf((*Scope)(nil))
+	instanceResolverType = reflect.TypeOf((*InstanceResolver)(nil)).Elem()
+	injectedWrapperType  = reflect.TypeOf((*injectedWrapper)(nil)).Elem()
+)
+
+// injectedWrapper is implemented by Injected, which wraps a resolved instance together with its registration
+type injectedWrapper interface {
+	injectedType() reflect.Type
+}
+
+// injectedTypeOf returns the type to be resolved for the type, i.e. the type of the instance wrapped by Injected
+func injectedTypeOf(tpe reflect.Type) (reflect.Type, bool) {
+	if tpe.Kind() != reflect.Struct || !tpe.Implements(injectedWrapperType) {
+		return tpe, false
+	}
+	return reflect.Zero(tpe).Interface().(injectedWrapper).injectedType(), true
+}
+
+// wrapInjected wraps the instance resolved from the registration by the Injected type
+func wrapInjected(tpe reflect.Type, instance reflect.Value, registration *Registration) reflect.Value {
+	result := reflect.New(tpe).Elem()
+	if instance.IsValid() {
+		result.FieldByName("Value").Set(instance)
+	}
+	result.FieldByName("Registration").Set(reflect.ValueOf(registration))
+	return result
+}
+
+// resolvesToScope denotes the type is resolved to the resolving scope itself, if there is no candidate for it:
+// *Scope and InstanceResolver, e.g. for components performing dynamic lookups. Since registrations are created and
+// wired in the scope owning them, the registrations of a parent never get the scope of a child, e.g. of a request.
+func (s *Scope) resolvesToScope(tpe reflect.Type, tag TagValue, module *Module) bool {
+	return (tpe == scopeType || tpe == instanceResolverType) && len(s.lookupVisibleCandidates(tpe, tag, module)) == 0
+}
@@ -0,0 +1,32 @@
+//go:build go1.21
+// +build go1.21
+
+package di
+
+import "reflect"
+
+var _ injectedWrapper = Injected[any]{}
+
+// Injected wraps an instance of T together with the registration it has been resolved from, e.g. for dispatchers
+// routing by the qualifier of the registration at runtime. Injected[T] is resolved like T, a slice of Injected[T]
+// like a slice of T:
+//
+//	Handlers []di.Injected[Handler] `inject:"qualifier=*"`
+type Injected[T any] struct {
+	// Value is the resolved instance, the zero value if an optional injection has not been resolved
+	Value T
+	// Registration is the registration the Value has been resolved from, nil if it has not been resolved
+	Registration *Registration
+}
+
+// Qualifier returns the qualifier of the Registration, empty if the Value has not been resolved
+func (i Injected[T]) Qualifier() string {
+	if i.Registration == nil {
+		return ""
+	}
+	return i.Registration.Qualifier
+}
+
+func (Injected[T]) injectedType() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
@@ -0,0 +1,56 @@
+//go:build go1.21
+// +build go1.21
+
+package di_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type InjectedComponent struct {
+	A        di.Injected[ValueA]       `inject:""`
+	Optional di.Injected[ValueB]       `inject:"optional"`
+	All      []di.Injected[InterfaceA] `inject:"qualifier=*"`
+}
+
+var _ = Describe("Injected", func() {
+	var sut *di.Scope
+	BeforeEach(func() {
+		sut = &di.Scope{}
+	})
+	It("should inject the values with their registrations", func() {
+		regA := sut.MustRegister(ValueA("a"))
+		regA1 := sut.MustRegister(&ComponentA1{})
+		regA1.Qualifier = "a1"
+		regA2 := sut.MustRegister(&ComponentA2{})
+		regA2.Qualifier, regA2.Priority = "a2", -1
+		target := &InjectedComponent{}
+		sut.MustWire(target)
+		Expect(target.A.Value).To(Equal(ValueA("a")))
+		Expect(target.A.Registration).To(BeIdenticalTo(regA))
+		Expect(target.A.Qualifier()).To(BeEmpty())
+		Expect(target.Optional.Registration).To(BeNil())
+		Expect(target.Optional.Qualifier()).To(BeEmpty())
+		Expect(target.All).To(HaveLen(2))
+		Expect(target.All[0].Registration).To(BeIdenticalTo(regA2))
+		Expect(target.All[1].Registration).To(BeIdenticalTo(regA1))
+		Expect(target.All[1].Qualifier()).To(Equal("a1"))
+		Expect(target.All[1].Value.GetA()).To(Equal("a"))
+	})
+	It("should resolve injected parameters", func() {
+		sut.MustRegister(ValueA("a"))
+		result := sut.MustInvoke(func(a di.Injected[ValueA]) string {
+			return string(a.Value) + a.Registration.Source
+		})
+		Expect(result[0].String()).To(ContainSubstring("injected_generic_test.go"))
+	})
+	It("should explain the resolution of the wrapped type", func() {
+		sut.MustRegister(ValueA("a"))
+		explanation := sut.Explain(reflect.TypeOf(di.Injected[ValueA]{}), di.TagValue{})
+		Expect(explanation.Chosen()).To(HaveLen(1))
+	})
+})
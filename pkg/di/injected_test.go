@@ -0,0 +1,71 @@
+package di_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type Dispatcher struct {
+	Scope    *di.Scope           `inject:""`
+	Resolver di.InstanceResolver `inject:""`
+}
+
+func (d *Dispatcher) Lookup(qualifier string) (reflect.Value, error) {
+	return d.Resolver.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{Qualifier: qualifier, Required: true})
+}
+
+var _ = Describe("Scope injection", func() {
+	var sut *di.Scope
+	BeforeEach(func() {
+		sut = &di.Scope{}
+	})
+	It("should inject the resolving scope", func() {
+		sut.MustRegister(ValueA("a")).Qualifier = "a"
+		child := sut.NewChild()
+		dispatcher := &Dispatcher{}
+		child.MustWire(dispatcher)
+		Expect(dispatcher.Scope).To(BeIdenticalTo(child))
+		Expect(dispatcher.Resolver).To(BeIdenticalTo(child))
+		value, err := dispatcher.Lookup("a")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(value.Interface()).To(Equal(ValueA("a")))
+	})
+	It("should inject the owning scope into the registrations of a parent", func() {
+		sut.MustRegister(ValueA("a")).Qualifier = "a"
+		sut.MustRegister(func() *Dispatcher { return &Dispatcher{} })
+		child := sut.NewChild()
+		target := &struct {
+			Dispatcher *Dispatcher `inject:""`
+		}{}
+		child.MustWire(target)
+		Expect(child.Close()).To(Succeed())
+		Expect(target.Dispatcher.Scope).To(BeIdenticalTo(sut))
+		Expect(target.Dispatcher.Resolver).To(BeIdenticalTo(sut))
+		_, err := target.Dispatcher.Lookup("a")
+		Expect(err).NotTo(HaveOccurred())
+	})
+	It("should resolve the scope for factories", func() {
+		sut.MustRegister(func(in struct {
+			di.In
+			Scope    *di.Scope           `inject:""`
+			Resolver di.InstanceResolver `inject:""`
+		}) ValueA {
+			Expect(in.Resolver).To(BeIdenticalTo(in.Scope))
+			return "a"
+		})
+		Expect(sut.MustInvoke(func(a ValueA, scope *di.Scope) bool {
+			return a == "a" && scope == sut
+		})[0].Bool()).To(BeTrue())
+	})
+	It("should prefer registered candidates", func() {
+		other := &di.Scope{}
+		sut.MustRegister(other)
+		dispatcher := &Dispatcher{}
+		sut.MustWire(dispatcher)
+		Expect(dispatcher.Scope).To(BeIdenticalTo(other))
+		Expect(dispatcher.Resolver).To(BeIdenticalTo(other))
+	})
+})
@@ -2,6 +2,7 @@ package di
 
 import (
 	"reflect"
+	"unsafe"
 
 	"github.com/pkg/errors"
 )
@@ -9,7 +10,8 @@ import (
 // Injection represents an injection to a reflect.StructField
 type Injection reflect.StructField
 
-// Apply injects the field to the provided reflect.Value
+// Apply injects the field to the provided reflect.Value.
+// Kindly note: unexported fields are set using unsafe, InjectableFrom only creates Injection for exported fields.
 func (i Injection) Apply(target reflect.Value, val reflect.Value) error {
 	// Inject to struct ptrs only
 	if (target.Kind() != reflect.Ptr && target.Kind() != reflect.Interface) || target.Elem().Kind() != reflect.Struct {
@@ -22,6 +24,9 @@ func (i Injection) Apply(target reflect.Value, val reflect.Value) error {
 	if !isCoercible(fld.Type(), val.Type()) {
 		return errNotCoercible(fld.Type(), val.Type())
 	}
+	if !reflect.StructField(i).IsExported() {
+		fld = reflect.NewAt(fld.Type(), unsafe.Pointer(fld.UnsafeAddr())).Elem() // nolint:gosec
+	}
 	fld.Set(val)
 	return nil
 }
@@ -28,6 +28,12 @@ var _ = Describe("Injection", func() {
 			Expect(sut.Apply(reflect.ValueOf(&tgtB), reflect.ValueOf(&tgtA))).NotTo(HaveOccurred())
 			Expect(tgtB.A).To(Equal(&tgtA))
 		})
+		It("should inject into unexported field", func() {
+			tgt := &UnexportedComponent{}
+			unexported := di.Injection(reflect.TypeOf(tgt).Elem().Field(0))
+			Expect(unexported.Apply(reflect.ValueOf(tgt), reflect.ValueOf(&tgtA))).NotTo(HaveOccurred())
+			Expect(tgt.a).To(Equal(&tgtA))
+		})
 		It("should error if not struct ptr", func() {
 			Expect(sut.Apply(reflect.ValueOf(tgtA), reflectValValue)).To(
 				MatchError(ContainSubstring("expected a struct pointer")),
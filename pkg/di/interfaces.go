@@ -7,3 +7,9 @@ type InstanceResolver interface {
 	// ResolveInstance resolves the reflect.Value for the provided reflect.Type and qualifier
 	ResolveInstance(tpe reflect.Type, tag TagValue) (reflect.Value, error)
 }
+
+// PropertySource is the interface for looking up configuration properties
+type PropertySource interface {
+	// Property returns the raw value for the provided key (e.g. database.host) and whether it was found
+	Property(key string) (string, bool)
+}
@@ -0,0 +1,34 @@
+package di
+
+// LookupStrategy denotes how the candidates of a scope and its parents are combined for the resolution
+type LookupStrategy int
+
+const (
+	// LookupDefault uses the strategy of the scope for TagValue and LookupMerge for Scope
+	LookupDefault LookupStrategy = iota
+	// LookupMerge merges the candidates of the scope and its parents, to be resolved by priority
+	LookupMerge
+	// LookupChildFirst merges the candidates of the scope and its parents, the registrations of a scope shadow the
+	// registrations of its parents for the same type and qualifier
+	LookupChildFirst
+	// LookupParentFirst uses the candidates of the farthest scope providing any, starting with the root scope
+	LookupParentFirst
+	// LookupIsolated uses the candidates of the scope only, ignoring the parents
+	LookupIsolated
+)
+
+// String returns a descriptor for the LookupStrategy
+func (l LookupStrategy) String() string {
+	switch l {
+	case LookupMerge:
+		return "merge"
+	case LookupChildFirst:
+		return "child-first"
+	case LookupParentFirst:
+		return "parent-first"
+	case LookupIsolated:
+		return "isolated"
+	default:
+		return "default"
+	}
+}
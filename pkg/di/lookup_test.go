@@ -0,0 +1,21 @@
+package di_test
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("LookupStrategy", func() {
+	Context("String()", func() {
+		It("should describe the strategy", func() {
+			Expect([]string{
+				di.LookupDefault.String(),
+				di.LookupMerge.String(),
+				di.LookupChildFirst.String(),
+				di.LookupParentFirst.String(),
+				di.LookupIsolated.String(),
+			}).To(Equal([]string{"default", "merge", "child-first", "parent-first", "isolated"}))
+		})
+	})
+})
@@ -0,0 +1,79 @@
+package di
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MethodPrefixInject is the prefix of exported methods to be called with resolved parameters on wiring, if enabled
+	// by Scope#InjectMethods
+	MethodPrefixInject = "Inject"
+)
+
+var errorType = reflect.TypeOf((*error)(nil)).Elem()
+
+// MethodInjection represents an injection calling a reflect.Method with resolved parameters
+type MethodInjection reflect.Method
+
+// IsInjectMethod checks if the method is to be called on wiring, i.e. if it is named with the MethodPrefixInject
+// followed by an upper case letter, e.g. InjectLogger, but not InjectionCount
+func IsInjectMethod(method reflect.Method) bool {
+	if !method.IsExported() || !strings.HasPrefix(method.Name, MethodPrefixInject) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(method.Name[len(MethodPrefixInject):])
+	return unicode.IsUpper(next)
+}
+
+// isPromoted denotes if the method of the struct ptr is promoted from an embedded field of the struct, i.e. if any
+// embedded field has a method with the same name
+func isPromoted(tpe reflect.Type, method reflect.Method) bool {
+	for i := 0; i < tpe.NumField(); i++ {
+		field := tpe.Field(i)
+		if !field.Anonymous {
+			continue
+		}
+		fieldType := field.Type
+		if kind := fieldType.Kind(); kind != reflect.Ptr && kind != reflect.Interface {
+			fieldType = reflect.PtrTo(fieldType)
+		}
+		if _, found := fieldType.MethodByName(method.Name); found {
+			return true
+		}
+	}
+	return false
+}
+
+// MethodInjectionFrom creates a MethodInjection, validating the method returns nothing or an error only
+func MethodInjectionFrom(tpe reflect.Type, method reflect.Method) (MethodInjection, error) {
+	switch outCount := method.Type.NumOut(); {
+	case method.Type.IsVariadic():
+		return MethodInjection{}, errInvalidInjectMethod(tpe, method, "must not be variadic")
+	case outCount > 1 || (outCount == 1 && method.Type.Out(0) != errorType):
+		return MethodInjection{}, errInvalidInjectMethod(tpe, method, "should return nothing or error")
+	}
+	return MethodInjection(method), nil
+}
+
+// Apply calls the method on the provided reflect.Value with the parameters resolved by the InstanceResolver
+func (m MethodInjection) Apply(target reflect.Value, resolver InstanceResolver) error {
+	method := target.MethodByName(m.Name)
+	if !method.IsValid() {
+		return errors.Errorf("method '%v' is not valid in target: %v", m.Name, target.Type())
+	}
+	// the receiver is the first parameter of the method type
+	args, err := resolveArguments(resolver, parametersOf(m.Type, 1))
+	if err != nil {
+		return err
+	}
+	results := method.Call(args)
+	if len(results) > 0 && !results[0].IsNil() {
+		return results[0].Interface().(error)
+	}
+	return nil
+}
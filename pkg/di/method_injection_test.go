@@ -0,0 +1,67 @@
+package di_test
+
+import (
+	"errors"
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsInjectMethod()", func() {
+	It("should match by prefix", func() {
+		method, _ := reflect.TypeOf(&ComponentM{}).MethodByName("InjectA")
+		Expect(di.IsInjectMethod(method)).To(BeTrue())
+		method, _ = reflect.TypeOf(&ComponentM{}).MethodByName("GetA")
+		Expect(di.IsInjectMethod(method)).To(BeFalse())
+		method, _ = reflect.TypeOf(&ComponentM{}).MethodByName("InjectionCount")
+		Expect(di.IsInjectMethod(method)).To(BeFalse())
+	})
+})
+
+var _ = Describe("MethodInjectionFrom()", func() {
+	It("should error on invalid return values", func() {
+		method, _ := reflect.TypeOf(&InvalidMethodComponent{}).MethodByName("InjectMeh")
+		_, err := di.MethodInjectionFrom(method.Type, method)
+		Expect(err).To(MatchError(ContainSubstring("should return nothing or error")))
+	})
+	It("should error on variadic methods", func() {
+		method, _ := reflect.TypeOf(&VariadicMethodComponent{}).MethodByName("InjectMeh")
+		_, err := di.MethodInjectionFrom(method.Type, method)
+		Expect(err).To(MatchError(ContainSubstring("must not be variadic")))
+	})
+})
+
+var _ = Describe("MethodInjection", func() {
+	var sut di.MethodInjection
+	var resolver testResolver
+	var tgt *ComponentM
+	BeforeEach(func() {
+		method, _ := reflect.TypeOf(&ComponentM{}).MethodByName("InjectA")
+		var err error
+		sut, err = di.MethodInjectionFrom(method.Type, method)
+		Expect(err).NotTo(HaveOccurred())
+		tgt = &ComponentM{}
+		resolver = testResolver{value: reflect.ValueOf(&ComponentA1{A: "a"})}
+	})
+	Context("Apply()", func() {
+		It("should call the method with resolved parameters", func() {
+			Expect(sut.Apply(reflect.ValueOf(tgt), resolver)).To(Succeed())
+			Expect(tgt.GetA()).To(Equal("a"))
+		})
+		It("should return error from resolver", func() {
+			resolver.err = errors.New("meh")
+			Expect(sut.Apply(reflect.ValueOf(tgt), resolver)).To(MatchError(ContainSubstring("meh")))
+		})
+		It("should return error from method", func() {
+			tgt.err = errors.New("meh")
+			Expect(sut.Apply(reflect.ValueOf(tgt), resolver)).To(MatchError("meh"))
+		})
+		It("should error if method does not exist on target", func() {
+			Expect(sut.Apply(reflect.ValueOf(&ComponentA1{}), resolver)).To(
+				MatchError(ContainSubstring("method 'InjectA' is not valid in target")),
+			)
+		})
+	})
+})
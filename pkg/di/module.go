@@ -0,0 +1,107 @@
+package di
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+)
+
+// Module is a named group of registrations installed by Scope#Install. The registrations of a module are only
+// visible to the components of the same module, unless they are exported by the module.
+type Module struct {
+	// Name is the name of the module, e.g. used in errors
+	Name string
+	// Register registers the components of the module in the scope
+	Register func(scope *Scope) error
+	// Dependencies are the modules installed before this module
+	Dependencies []*Module
+	// Exports are the types visible to components outside of this module: a registration is visible if its type is
+	// exported or if it is injected as an exported type, e.g. an exported interface implemented by the registration
+	Exports []reflect.Type
+}
+
+// String returns the name of the module
+func (m *Module) String() string {
+	return m.Name
+}
+
+// exports denotes if the type is exported by the module
+func (m *Module) exports(tpe reflect.Type) bool {
+	for _, exported := range m.Exports {
+		if exported == tpe {
+			return true
+		}
+	}
+	return false
+}
+
+// Install installs the modules and their dependencies in this scope. Installing a module already installed in this
+// scope or one of its parents has no effect. If a module cannot be installed, its registrations are removed.
+func (s *Scope) Install(modules ...*Module) error {
+	for _, module := range modules {
+		if err := s.install(module, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MustInstall calls Install and panics on errors
+func (s *Scope) MustInstall(modules ...*Module) {
+	s.panicOnErr(s.Install(modules...))
+}
+
+// install installs the module after its dependencies, path are the modules depending on it
+func (s *Scope) install(module *Module, path []*Module) error {
+	if s.isInstalled(module) {
+		return nil
+	}
+	for _, dependent := range path {
+		if dependent == module {
+			return errors.Errorf("module dependency cycle detected: %v", append(path, module))
+		}
+	}
+	for _, dependency := range module.Dependencies {
+		if err := s.install(dependency, append(path, module)); err != nil {
+			return err
+		}
+	}
+	if module.Register != nil {
+		previous := s.installing
+		s.installing = module
+		err := module.Register(s)
+		s.installing = previous
+		if err != nil {
+			for _, reg := range s.registrations.filter(func(reg *Registration) bool { return reg.module == module }) {
+				_, _ = s.remove(reg)
+			}
+			return errors.Wrapf(err, "could not install module %v", module)
+		}
+	}
+	if s.installed == nil {
+		s.installed = map[*Module]bool{}
+	}
+	s.installed[module] = true
+	return nil
+}
+
+// isInstalled denotes if the module is installed in this scope or one of its parents
+func (s *Scope) isInstalled(module *Module) bool {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if scope.installed[module] {
+			return true
+		}
+	}
+	return false
+}
+
+// Module returns the module the registration has been installed by, nil if it has been registered otherwise
+func (r *Registration) Module() *Module {
+	return r.module
+}
+
+// visibleTo denotes if the registration may be injected as the requested type into a component of the module
+// (nil outside of modules)
+func (r *Registration) visibleTo(module *Module, requested reflect.Type) bool {
+	return r.module == nil || r.module == module || r.module.exports(r.Type) || r.module.exports(requested)
+}
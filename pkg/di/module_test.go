@@ -0,0 +1,107 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type ModuleComponent struct {
+	A ValueA `inject:""`
+}
+
+func (c *ModuleComponent) GetA() string { return string(c.A) }
+
+var _ = Describe("Module", func() {
+	var sut *di.Scope
+	var installs int
+	var internal, public *di.Module
+	BeforeEach(func() {
+		sut = &di.Scope{}
+		installs = 0
+		internal = &di.Module{
+			Name: "internal",
+			Register: func(scope *di.Scope) error {
+				installs++
+				scope.MustRegister(ValueA("internal"))
+				scope.MustRegister(&ModuleComponent{})
+				return nil
+			},
+			Exports: []reflect.Type{reflect.TypeOf((*InterfaceA)(nil)).Elem()},
+		}
+		public = &di.Module{
+			Name:         "public",
+			Dependencies: []*di.Module{internal},
+			Register: func(scope *di.Scope) error {
+				scope.MustRegister(func(in struct {
+					di.In
+					A InterfaceA `inject:""`
+				}) ValueB {
+					return ValueB(in.A.GetA())
+				})
+				return nil
+			},
+			Exports: []reflect.Type{reflect.TypeOf(ValueB(""))},
+		}
+	})
+	It("should install the modules after their dependencies once", func() {
+		Expect(sut.Install(public, internal)).To(Succeed())
+		sut.MustInstall(internal)
+		sut.NewChild().MustInstall(public)
+		Expect(installs).To(Equal(1))
+		Expect(sut.Registrations()).To(HaveLen(3))
+		Expect(sut.Registrations()[0].Module()).To(BeIdenticalTo(internal))
+		Expect(sut.Registrations()[2].Module()).To(BeIdenticalTo(public))
+	})
+	It("should hide registrations not exported", func() {
+		sut.MustInstall(public)
+		Expect(sut.Wire(&ModuleComponent{})).To(MatchError(ContainSubstring("no candidate found for: di_test.ValueA")))
+		Expect(sut.Candidates(reflect.TypeOf(ValueA("")), di.TagValue{})).To(BeEmpty())
+		Expect(sut.Candidates(reflect.TypeOf(&ModuleComponent{}), di.TagValue{})).To(BeEmpty())
+		Expect(sut.Candidates(reflect.TypeOf((*InterfaceA)(nil)).Elem(), di.TagValue{})).To(HaveLen(1))
+		Expect(sut.Explain(reflect.TypeOf(ValueA("")), di.TagValue{}).Registrations[0].Verdict).
+			To(Equal(di.VerdictNotExported))
+		target := &struct {
+			B ValueB     `inject:""`
+			A InterfaceA `inject:""`
+		}{}
+		sut.MustWire(target)
+		Expect(target.B).To(Equal(ValueB("internal")))
+		Expect(target.A.GetA()).To(Equal("internal"))
+	})
+	It("should consider the visibility for the dependency graph", func() {
+		sut.MustInstall(public)
+		sut.Registrations()[2].Eager()
+		sut.MustRegister(ValueA("outside")).Priority = 1
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(sut.MustInvoke(func(b ValueB, a ValueA) string { return string(b) + " " + string(a) })[0].String()).
+			To(Equal("internal outside"))
+	})
+	It("should remove the registrations of a failed module", func() {
+		failing := &di.Module{Name: "failing", Register: func(scope *di.Scope) error {
+			scope.MustRegister(ValueA("a"))
+			return errors.New("meh")
+		}}
+		Expect(sut.Install(failing)).To(MatchError("could not install module failing: meh"))
+		Expect(sut.Registrations()).To(BeEmpty())
+		Expect(sut.Install(&di.Module{Name: "ok"})).To(Succeed())
+	})
+	It("should error on dependency cycles", func() {
+		a, b := &di.Module{Name: "a"}, &di.Module{Name: "b"}
+		a.Dependencies, b.Dependencies = []*di.Module{b}, []*di.Module{a}
+		Expect(sut.Install(a)).To(MatchError("module dependency cycle detected: [a b a]"))
+		Expect(func() { sut.MustInstall(a) }).To(Panic())
+	})
+	It("should keep the modules on Clone", func() {
+		sut.MustInstall(public)
+		clone := sut.Clone()
+		clone.MustInstall(internal)
+		Expect(installs).To(Equal(1))
+		Expect(clone.Registrations()[0].Module()).To(BeIdenticalTo(internal))
+		Expect(clone.Wire(&ModuleComponent{})).To(HaveOccurred())
+	})
+})
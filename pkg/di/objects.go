@@ -0,0 +1,47 @@
+package di
+
+import "reflect"
+
+var (
+	inType  = reflect.TypeOf(In{})
+	outType = reflect.TypeOf(Out{})
+)
+
+// In is the marker to be embedded in parameter objects, see IsParameterObject
+type In struct{}
+
+// Out is the marker to be embedded in result objects, see IsResultObject
+type Out struct{}
+
+// IsParameterObject checks if the type is a struct (not a ptr) embedding In or having fields to be injected.
+// Parameter objects are created and injected for function parameters instead of being resolved themselves.
+func IsParameterObject(tpe reflect.Type) bool {
+	if tpe.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < tpe.NumField(); i++ {
+		structFld := tpe.Field(i)
+		if _, hasTag := structFld.Tag.Lookup(TagKey); hasTag || isMarker(structFld, inType) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsResultObject checks if the type is a struct (not a ptr) embedding Out.
+// The exported fields of result objects returned by factories are registered as separate components.
+func IsResultObject(tpe reflect.Type) bool {
+	if tpe.Kind() != reflect.Struct {
+		return false
+	}
+	for i := 0; i < tpe.NumField(); i++ {
+		if isMarker(tpe.Field(i), outType) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMarker(fld reflect.StructField, marker reflect.Type) bool {
+	return fld.Anonymous && fld.Type == marker
+}
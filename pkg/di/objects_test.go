@@ -0,0 +1,36 @@
+package di_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsParameterObject()", func() {
+	It("should accept structs embedding In", func() {
+		Expect(di.IsParameterObject(reflect.TypeOf(struct{ di.In }{}))).To(BeTrue())
+	})
+	It("should accept structs with inject tags", func() {
+		Expect(di.IsParameterObject(reflect.TypeOf(ParamsA{}))).To(BeTrue())
+	})
+	It("should not accept struct ptrs", func() {
+		Expect(di.IsParameterObject(reflect.TypeOf(&ParamsA{}))).To(BeFalse())
+	})
+	It("should not accept structs without inject tags", func() {
+		Expect(di.IsParameterObject(reflect.TypeOf(struct{ A string }{}))).To(BeFalse())
+	})
+})
+
+var _ = Describe("IsResultObject()", func() {
+	It("should accept structs embedding Out", func() {
+		Expect(di.IsResultObject(reflect.TypeOf(OutAB{}))).To(BeTrue())
+	})
+	It("should not accept struct ptrs", func() {
+		Expect(di.IsResultObject(reflect.TypeOf(&OutAB{}))).To(BeFalse())
+	})
+	It("should not accept structs with Out as named field", func() {
+		Expect(di.IsResultObject(reflect.TypeOf(struct{ Out di.Out }{}))).To(BeFalse())
+	})
+})
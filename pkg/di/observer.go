@@ -0,0 +1,116 @@
+package di
+
+import (
+	"reflect"
+	"time"
+)
+
+var (
+	_ Observer = NopObserver{}
+	_ Observer = Observers{}
+)
+
+// Resolution describes the resolution of a type by a Scope
+type Resolution struct {
+	// Type is the type to be resolved
+	Type reflect.Type
+	// Tag is the tag value used for the resolution
+	Tag TagValue
+	// Candidates are the candidates found for the type and tag, ordered by priority
+	Candidates Registrations
+	// Chosen are the candidates chosen to be injected
+	Chosen Registrations
+	// Duration is the time the resolution took, including the creation and wiring of the chosen instances
+	Duration time.Duration
+	// Err is the error of the resolution, if any
+	Err error
+}
+
+// Observer receives the events of a Scope, embed NopObserver to implement only a subset of the events
+type Observer interface {
+	// RegistrationAdded is called when a registration is added to the scope
+	RegistrationAdded(registration *Registration)
+	// CandidatesFiltered is called with the candidates for a type and tag value, ordered by priority
+	CandidatesFiltered(tpe reflect.Type, tag TagValue, candidates Registrations)
+	// CandidateChosen is called for each candidate chosen to be injected for a type and tag value
+	CandidateChosen(tpe reflect.Type, tag TagValue, chosen *Registration)
+	// Resolved is called when the resolution of a type has been finished
+	Resolved(resolution Resolution)
+	// FactoryStarted is called before the factory of a registration is called
+	FactoryStarted(registration *Registration)
+	// FactoryFinished is called after the factory of a registration has been called, the duration includes the
+	// resolution of the factory's parameters (see RegistrationStats for the time spent by the registration itself)
+	FactoryFinished(registration *Registration, duration time.Duration, err error)
+	// InstanceWired is called after a created instance of a registration has been wired
+	InstanceWired(registration *Registration, duration time.Duration, err error)
+	// FieldInjected is called after a value has been injected to the field of the target
+	FieldInjected(target reflect.Value, injection Injection, value reflect.Value)
+}
+
+// NopObserver is an Observer ignoring all events
+type NopObserver struct{}
+
+func (NopObserver) RegistrationAdded(*Registration)                          {}
+func (NopObserver) CandidatesFiltered(reflect.Type, TagValue, Registrations) {}
+func (NopObserver) CandidateChosen(reflect.Type, TagValue, *Registration)    {}
+func (NopObserver) Resolved(Resolution)                                      {}
+func (NopObserver) FactoryStarted(*Registration)                             {}
+func (NopObserver) FactoryFinished(*Registration, time.Duration, error)      {}
+func (NopObserver) InstanceWired(*Registration, time.Duration, error)        {}
+func (NopObserver) FieldInjected(reflect.Value, Injection, reflect.Value)    {}
+
+// Observers is an Observer passing the events to all of its observers
+type Observers []Observer
+
+func (o Observers) RegistrationAdded(registration *Registration) {
+	for _, observer := range o {
+		observer.RegistrationAdded(registration)
+	}
+}
+
+func (o Observers) CandidatesFiltered(tpe reflect.Type, tag TagValue, candidates Registrations) {
+	for _, observer := range o {
+		observer.CandidatesFiltered(tpe, tag, candidates)
+	}
+}
+
+func (o Observers) CandidateChosen(tpe reflect.Type, tag TagValue, chosen *Registration) {
+	for _, observer := range o {
+		observer.CandidateChosen(tpe, tag, chosen)
+	}
+}
+
+func (o Observers) Resolved(resolution Resolution) {
+	for _, observer := range o {
+		observer.Resolved(resolution)
+	}
+}
+
+func (o Observers) FactoryStarted(registration *Registration) {
+	for _, observer := range o {
+		observer.FactoryStarted(registration)
+	}
+}
+
+func (o Observers) FactoryFinished(registration *Registration, duration time.Duration, err error) {
+	for _, observer := range o {
+		observer.FactoryFinished(registration, duration, err)
+	}
+}
+
+func (o Observers) InstanceWired(registration *Registration, duration time.Duration, err error) {
+	for _, observer := range o {
+		observer.InstanceWired(registration, duration, err)
+	}
+}
+
+func (o Observers) FieldInjected(target reflect.Value, injection Injection, value reflect.Value) {
+	for _, observer := range o {
+		observer.FieldInjected(target, injection, value)
+	}
+}
+
+// injectionObserver is implemented by resolvers to be notified about injected fields, see Injectable#Apply
+type injectionObserver interface {
+	fieldInjected(target reflect.Value, injection Injection, value reflect.Value)
+}
@@ -0,0 +1,115 @@
+package di_test
+
+import (
+	"errors"
+	"reflect"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type recordingObserver struct {
+	di.NopObserver
+	events      []string
+	resolutions []di.Resolution
+	errs        []error
+}
+
+func (o *recordingObserver) RegistrationAdded(registration *di.Registration) {
+	o.events = append(o.events, "added "+registration.String())
+}
+
+func (o *recordingObserver) CandidatesFiltered(tpe reflect.Type, _ di.TagValue, candidates di.Registrations) {
+	o.events = append(o.events, "filtered "+tpe.String())
+}
+
+func (o *recordingObserver) CandidateChosen(tpe reflect.Type, _ di.TagValue, chosen *di.Registration) {
+	o.events = append(o.events, "chosen "+chosen.Type.String())
+}
+
+func (o *recordingObserver) Resolved(resolution di.Resolution) {
+	o.resolutions = append(o.resolutions, resolution)
+}
+
+func (o *recordingObserver) FactoryStarted(registration *di.Registration) {
+	o.events = append(o.events, "started "+registration.Type.String())
+}
+
+func (o *recordingObserver) FactoryFinished(registration *di.Registration, _ time.Duration, err error) {
+	o.events = append(o.events, "finished "+registration.Type.String())
+	o.errs = append(o.errs, err)
+}
+
+func (o *recordingObserver) InstanceWired(registration *di.Registration, _ time.Duration, err error) {
+	o.events = append(o.events, "wired "+registration.Type.String())
+}
+
+func (o *recordingObserver) FieldInjected(target reflect.Value, injection di.Injection, _ reflect.Value) {
+	o.events = append(o.events, "injected "+target.Type().String()+"."+injection.Name)
+}
+
+var _ = Describe("Observer", func() {
+	var observer *recordingObserver
+	var sut *di.Scope
+	BeforeEach(func() {
+		observer = &recordingObserver{}
+		sut = di.NewScope(di.WithObserver(observer))
+	})
+	It("should observe added registrations", func() {
+		reg := sut.MustRegister(ValueA("a"))
+		Expect(observer.events).To(Equal([]string{"added " + reg.String()}))
+		Expect(reg.Source).NotTo(BeEmpty())
+	})
+	It("should observe the resolution", func() {
+		sut.MustRegister(ValueA("a"))
+		sut.MustRegister(func() *ComponentA1 { return &ComponentA1{} })
+		observer.events = nil
+		_, err := sut.ResolveInstance(reflect.TypeOf(&ComponentA1{}), di.TagValue{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(observer.events).To(Equal([]string{
+			"filtered *di_test.ComponentA1",
+			"chosen *di_test.ComponentA1",
+			"started *di_test.ComponentA1",
+			"finished *di_test.ComponentA1",
+			"filtered di_test.ValueB",
+			"filtered di_test.ValueA",
+			"chosen di_test.ValueA",
+			"started di_test.ValueA",
+			"finished di_test.ValueA",
+			"injected *di_test.ComponentA1.A",
+			"wired *di_test.ComponentA1",
+		}))
+		Expect(observer.resolutions).To(HaveLen(3))
+		Expect(observer.resolutions[2].Type).To(Equal(reflect.TypeOf(&ComponentA1{})))
+		Expect(observer.resolutions[2].Chosen).To(HaveLen(1))
+		Expect(observer.resolutions[2].Err).NotTo(HaveOccurred())
+	})
+	It("should observe factory errors", func() {
+		sut.MustRegister(func() (ValueA, error) { return "", errors.New("fail") })
+		_, err := sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+		Expect(err).To(HaveOccurred())
+		Expect(observer.errs).To(ConsistOf(MatchError(ContainSubstring("fail"))))
+		Expect(observer.resolutions[0].Err).To(Equal(err))
+	})
+	It("should not observe the factory of created instances", func() {
+		sut.MustRegister(ValueA("a"))
+		_, _ = sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+		observer.events = nil
+		_, _ = sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+		Expect(observer.events).To(Equal([]string{"filtered di_test.ValueA", "chosen di_test.ValueA"}))
+	})
+	It("should pass the observers to child scopes", func() {
+		child := sut.NewChild()
+		child.MustRegister(ValueA("a"))
+		Expect(observer.events).To(HaveLen(1))
+	})
+	It("should pass the events to all observers", func() {
+		other := &recordingObserver{}
+		observers := di.Observers{observer, other}
+		observers.FactoryStarted(&di.Registration{Type: reflect.TypeOf("")})
+		Expect(observer.events).To(Equal([]string{"started string"}))
+		Expect(other.events).To(Equal(observer.events))
+	})
+})
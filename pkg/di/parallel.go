@@ -0,0 +1,156 @@
+package di
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+var (
+	_ InstanceResolver     = &parallelResolver{}
+	_ injectionObserver    = &parallelResolver{}
+	_ registrationResolver = &parallelResolver{}
+)
+
+// parallelInstantiation coordinates the concurrent instantiation of registrations, see Scope#Parallelism.
+// All accesses to the scope are synchronized by the mutex, only the factories are called concurrently.
+// Resolving a registration whose factory is being called by another worker waits for its instance, releasing the lock.
+type parallelInstantiation struct {
+	scope *Scope
+	ctx   context.Context
+	mutex sync.Mutex
+}
+
+// instantiateParallel instantiates the registrations given in dependency order using a pool of Scope#Parallelism
+// workers, a registration is instantiated once all of its dependencies have been instantiated
+func (s *Scope) instantiateParallel(ctx context.Context, order Registrations,
+	dependencies map[*Registration]Registrations) (map[*Registration]error, error) {
+	p := &parallelInstantiation{scope: s, ctx: ctx}
+	pending := map[*Registration]int{}
+	dependents := map[*Registration]Registrations{}
+	for _, reg := range order {
+		pending[reg] = len(dependencies[reg])
+		for _, dep := range dependencies[reg] {
+			dependents[dep] = append(dependents[dep], reg)
+		}
+	}
+	type result struct {
+		registration *Registration
+		err          error
+	}
+	tasks, results := make(chan *Registration), make(chan result, s.Parallelism)
+	for i := 0; i < s.Parallelism; i++ {
+		go func() {
+			for reg := range tasks {
+				results <- result{registration: reg, err: p.instantiate(reg)}
+			}
+		}()
+	}
+	defer close(tasks)
+	failed := map[*Registration]error{}
+	ready := order.filter(func(reg *Registration) bool { return pending[reg] == 0 })
+	running, finished := 0, 0
+	for len(ready) > 0 || running > 0 {
+		for len(ready) > 0 && running < s.Parallelism && ctx.Err() == nil {
+			tasks <- ready[0]
+			ready, running = ready[1:], running+1
+		}
+		if running == 0 {
+			break
+		}
+		res := <-results
+		running, finished = running-1, finished+1
+		if res.err != nil {
+			failed[res.registration] = res.err
+		}
+		for _, dependent := range dependents[res.registration] {
+			if pending[dependent]--; pending[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+	if finished < len(order) {
+		return failed, ctx.Err()
+	}
+	return failed, nil
+}
+
+// instantiate calls the factory of the registration without holding the lock and wires the created instance
+func (p *parallelInstantiation) instantiate(registration *Registration) error {
+	s := p.scope
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	c := &call{ctx: p.ctx, parallel: p}
+	// the registration may have been created by the resolution of another worker
+	_, begun, err := registration.beginCreation(c, c.wait())
+	if err != nil || !begun {
+		return err
+	}
+	defer registration.endCreation()
+	s.Observers.FactoryStarted(registration)
+	endSpan := s.startSpan(c, SpanNameFactory, traceAttributesOf(registration)...)
+	resolver := &parallelResolver{call: c, registration: registration}
+	start := time.Now()
+
+	p.mutex.Unlock()
+	instance, err := registration.create(resolver)
+	p.mutex.Lock()
+
+	endSpan(err)
+	total := time.Since(start)
+	s.factoryFinished(registration, total, total-resolver.resolving, err)
+	if err != nil {
+		return canceled(p.ctx, registration, err)
+	}
+	return s.wireCreated(c, registration, instance)
+}
+
+// waitCreation waits for the next ended creation releasing the lock. It is called holding the creationMutex, which is
+// acquired after the lock to keep the lock order.
+func (p *parallelInstantiation) waitCreation() {
+	p.mutex.Unlock()
+	creationCond.Wait()
+	creationMutex.Unlock()
+	p.mutex.Lock()
+	creationMutex.Lock()
+}
+
+// parallelResolver resolves the parameters of a factory called by a worker, holding the lock for each resolution
+type parallelResolver struct {
+	// call is the call of the worker, see call#parallel
+	call         *call
+	registration *Registration
+	// resolving is the time spent resolving the parameters, i.e. creating and wiring the dependencies
+	resolving time.Duration
+}
+
+func (r *parallelResolver) ResolveInstance(tpe reflect.Type, tag TagValue) (result reflect.Value, err error) {
+	r.locked(func(s *Scope) { result, err = s.resolveInstance(r.call, tpe, tag) })
+	return result, err
+}
+
+func (r *parallelResolver) resolveRegistration(registration *Registration) (instance interface{}, err error) {
+	r.locked(func(s *Scope) { instance, err = s.wiredInstance(r.call, registration) })
+	return instance, err
+}
+
+// locked calls the function holding the lock with the registration being created as consumer
+func (r *parallelResolver) locked(fn func(s *Scope)) {
+	start := time.Now()
+	p := r.call.parallel
+	p.mutex.Lock()
+	defer func() {
+		r.resolving += time.Since(start)
+		p.mutex.Unlock()
+	}()
+	defer r.call.consuming(r.registration.Type, r.registration.module)()
+	fn(p.scope)
+}
+
+func (r *parallelResolver) fieldInjected(target reflect.Value, injection Injection, value reflect.Value) {
+	p := r.call.parallel
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.scope.fieldInjected(target, injection, value)
+}
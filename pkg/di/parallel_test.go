@@ -0,0 +1,187 @@
+package di_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+type (
+	SlowA string
+	SlowB string
+	SlowC string
+	SlowD string
+)
+
+type InSlowA struct {
+	di.In
+	A SlowA `inject:""`
+}
+
+type InSlowB struct {
+	di.In
+	B SlowB `inject:""`
+}
+
+type SlowConsumer interface {
+	Slow() SlowA
+}
+
+type slowConsumer struct {
+	A SlowA `inject:""`
+}
+
+func (c *slowConsumer) Slow() SlowA { return c.A }
+
+var _ = Describe("InstantiateAll in parallel", func() {
+	const delay = 50 * time.Millisecond
+	var sut *di.Scope
+	var calls int32
+	var mutex sync.Mutex
+	var events []string
+	BeforeEach(func() {
+		sut = di.NewScope(di.WithParallelism(4))
+		calls, events = 0, nil
+	})
+	record := func(event string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		events = append(events, event)
+	}
+	slow := func(name string) func() {
+		return func() {
+			atomic.AddInt32(&calls, 1)
+			record("start " + name)
+			time.Sleep(delay)
+			record("end " + name)
+		}
+	}
+	It("should call independent factories concurrently", func() {
+		sut.MustRegister(func() SlowA { slow("a")(); return "a" }).Eager()
+		sut.MustRegister(func() SlowB { slow("b")(); return "b" }).Eager()
+		sut.MustRegister(func() SlowC { slow("c")(); return "c" }).Eager()
+		sut.MustRegister(func() SlowD { slow("d")(); return "d" }).Eager()
+		start := time.Now()
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(time.Since(start)).To(BeNumerically("<", 3*delay))
+		Expect(calls).To(BeEquivalentTo(4))
+	})
+	It("should instantiate the dependencies first and each registration once", func() {
+		sut.MustRegister(func(InSlowB) SlowC { slow("c")(); return "c" }).Eager()
+		sut.MustRegister(func(InSlowA) SlowB { slow("b")(); return "b" }).Eager()
+		sut.MustRegister(func() SlowA { slow("a")(); return "a" })
+		sut.MustRegister(func(InSlowA) SlowD { slow("d")(); return "d" }).Eager()
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(calls).To(BeEquivalentTo(4))
+		Expect(events[:2]).To(Equal([]string{"start a", "end a"}))
+		Expect(events).To(ContainElement("end b"))
+		Expect(events).To(ContainElement("start c"))
+		indexOf := func(event string) int {
+			for idx, e := range events {
+				if e == event {
+					return idx
+				}
+			}
+			return -1
+		}
+		Expect(indexOf("end b")).To(BeNumerically("<", indexOf("start c")))
+	})
+	It("should wait for dependencies unknown before the creation", func() {
+		sut.MustRegister(func() SlowA { slow("a")(); return "a" }).Eager()
+		sut.MustRegister(func() SlowConsumer { return &slowConsumer{} }).Eager()
+		sut.MustRegister(func() SlowB { slow("b")(); return "b" }).Eager()
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(calls).To(BeEquivalentTo(2))
+		consumer := &struct {
+			Consumer SlowConsumer `inject:""`
+		}{}
+		sut.MustWire(consumer)
+		Expect(consumer.Consumer.Slow()).To(BeEquivalentTo("a"))
+	})
+	It("should instantiate the same registrations as sequentially", func() {
+		instantiated := func(parallelism int) []string {
+			var created []string
+			create := func(name string) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				created = append(created, name)
+			}
+			parent := di.NewScope()
+			parent.MustRegister(func() SlowC { create("parent c"); return "c" })
+			scope := parent.NewChild(di.WithParallelism(parallelism))
+			scope.MustRegister(func() SlowA { create("a"); return "a" }).WithPriority(-1)
+			scope.MustRegister(func() SlowA { create("lower priority a"); return "a" })
+			scope.MustRegister(func(in struct {
+				di.In
+				A SlowA `inject:""`
+				C SlowC `inject:""`
+			}) SlowB {
+				create("b")
+				return "b"
+			}).Eager()
+			Expect(scope.InstantiateAll(context.Background())).To(Succeed())
+			sort.Strings(created)
+			return created
+		}
+		Expect(instantiated(4)).To(Equal(instantiated(1)))
+		Expect(instantiated(4)).To(Equal([]string{"a", "b", "parent c"}))
+	})
+	It("should instantiate result objects once", func() {
+		sut.MustRegister(func() OutAB {
+			slow("out")()
+			return OutAB{A: "a", A2: "a2", B: "b"}
+		}).Eager()
+		sut.MustRegister(func(in InA) SlowA { return SlowA(in.A) }).Eager()
+		sut.MustRegister(func(in struct {
+			di.In
+			B ValueB `inject:""`
+		}) SlowB {
+			return SlowB(in.B)
+		}).Eager()
+		Expect(sut.InstantiateAll(context.Background())).To(Succeed())
+		Expect(calls).To(BeEquivalentTo(1))
+		Expect(sut.MustInvoke(func(a SlowA, b SlowB) string { return string(a) + string(b) })[0].String()).
+			To(Equal("ab"))
+	})
+	It("should report the errors deterministically in dependency order", func() {
+		for i := 0; i < 5; i++ {
+			sut = di.NewScope(di.WithParallelism(4))
+			sut.MustRegister(func(InSlowA) (SlowC, error) { return "", errors.New("fail c") }).Eager()
+			sut.MustRegister(func() (SlowB, error) {
+				time.Sleep(delay)
+				return "", errors.New("fail b")
+			}).Eager()
+			sut.MustRegister(func() (SlowA, error) { return "", errors.New("fail a") }).Eager()
+			err := sut.InstantiateAll(context.Background())
+			Expect(err).To(BeAssignableToTypeOf(di.Errors{}))
+			errs := err.(di.Errors)
+			Expect(errs).To(HaveLen(3))
+			Expect(errs[0]).To(MatchError(ContainSubstring("fail a")))
+			Expect(errs[1]).To(MatchError(ContainSubstring("could not instantiate: component di_test.SlowC")))
+			Expect(errs[2]).To(MatchError(ContainSubstring("fail b")))
+		}
+	})
+	It("should detect dependency cycles", func() {
+		sut.MustRegister(func(InSlowB) SlowA { return "a" }).Eager()
+		sut.MustRegister(func(InSlowA) SlowB { return "b" }).Eager()
+		Expect(sut.InstantiateAll(context.Background())).To(MatchError(ContainSubstring("dependency cycle detected")))
+	})
+	It("should abort once the context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		sut.MustRegister(func() SlowA { cancel(); return "a" }).Eager()
+		sut.MustRegister(func(InSlowA) SlowB { slow("b")(); return "b" }).Eager()
+		Expect(sut.InstantiateAll(ctx)).To(MatchError(ContainSubstring("instantiation aborted: context canceled")))
+		Expect(calls).To(BeZero())
+	})
+	It("should set the parallelism", func() {
+		Expect(sut.Parallelism).To(Equal(4))
+		Expect(sut.NewChild().Parallelism).To(Equal(4))
+	})
+})
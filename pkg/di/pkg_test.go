@@ -1,8 +1,10 @@
 package di_test
 
 import (
+	"context"
 	"testing"
 
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
 	. "github.com/onsi/ginkgo/v2"
 
 	. "github.com/onsi/gomega"
@@ -57,3 +59,67 @@ type ComponentC struct {
 type AllValueA struct {
 	Values []ValueA `inject:"qualifier=*"`
 }
+
+type ComponentM struct {
+	a   InterfaceA
+	err error
+}
+
+func (c *ComponentM) InjectA(a InterfaceA) error {
+	c.a = a
+	return c.err
+}
+
+func (c *ComponentM) GetA() string { return c.a.GetA() }
+
+func (c *ComponentM) InjectionCount() int { return 1 }
+
+type EmbeddingComponentM struct {
+	ComponentM
+}
+
+type InvalidMethodComponent struct{}
+
+func (c *InvalidMethodComponent) InjectMeh() int { return 0 }
+
+type VariadicMethodComponent struct{}
+
+func (c *VariadicMethodComponent) InjectMeh(...ValueA) {}
+
+type UnexportedComponent struct {
+	a InterfaceA `inject:""`
+}
+
+type ParamsA struct {
+	A     ValueA     `inject:"qualifier=a"`
+	B     ValueB     `inject:"optional"`
+	All   []ValueA   `inject:"qualifier=*"`
+	Other InterfaceA `inject:"optional"`
+}
+
+type InA struct {
+	di.In
+	A ValueA `inject:""`
+}
+
+type OutAB struct {
+	di.Out
+	A  ValueA
+	A2 ValueA `inject:"qualifier=a2"`
+	B  ValueB
+}
+
+type InCycle struct {
+	di.In
+	B InterfaceB `inject:""`
+}
+
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string, _ ...di.TraceAttribute) (context.Context, di.Span) {
+	return ctx, nopSpan{}
+}
+
+type nopSpan struct{}
+
+func (nopSpan) End(error) {}
@@ -0,0 +1,71 @@
+package di
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+const (
+	// PropertyKeySeparator separates the segments of a property key, e.g. database.host
+	PropertyKeySeparator = "."
+)
+
+var (
+	_ PropertySource = EnvProperties{}
+	_ PropertySource = MapProperties{}
+	_ PropertySource = PropertySources{}
+)
+
+// EnvProperties is a PropertySource reading environment variables.
+// The property key is upper cased and separators are replaced by underscores, e.g. database.host -> DATABASE_HOST
+type EnvProperties struct{}
+
+func (EnvProperties) Property(key string) (string, bool) {
+	return os.LookupEnv(strings.NewReplacer(PropertyKeySeparator, "_", "-", "_").Replace(strings.ToUpper(key)))
+}
+
+// MapProperties is a PropertySource for nested maps, e.g. unmarshalled from YAML or JSON
+type MapProperties map[string]interface{}
+
+func (p MapProperties) Property(key string) (string, bool) {
+	var current interface{} = map[string]interface{}(p)
+	for _, segment := range strings.Split(key, PropertyKeySeparator) {
+		var found bool
+		switch values := current.(type) {
+		case MapProperties:
+			current, found = values[segment]
+		case map[string]interface{}:
+			current, found = values[segment]
+		case map[interface{}]interface{}:
+			current, found = values[segment]
+		}
+		if !found {
+			return "", false
+		}
+	}
+	switch value := current.(type) {
+	case nil, MapProperties, map[string]interface{}, map[interface{}]interface{}:
+		return "", false
+	case []interface{}:
+		parts := make([]string, len(value))
+		for idx, part := range value {
+			parts[idx] = fmt.Sprint(part)
+		}
+		return strings.Join(parts, ","), true
+	default:
+		return fmt.Sprint(value), true
+	}
+}
+
+// PropertySources combines multiple PropertySource, the first one providing a property wins
+type PropertySources []PropertySource
+
+func (p PropertySources) Property(key string) (string, bool) {
+	for _, source := range p {
+		if value, found := source.Property(key); found {
+			return value, true
+		}
+	}
+	return "", false
+}
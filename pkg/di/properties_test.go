@@ -0,0 +1,46 @@
+package di_test
+
+import (
+	"os"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvProperties", func() {
+	It("should map the key to an environment variable", func() {
+		Expect(os.Setenv("DI_TEST_MAX_CONNS", "3")).To(Succeed())
+		DeferCleanup(os.Unsetenv, "DI_TEST_MAX_CONNS")
+		value, found := di.EnvProperties{}.Property("di_test.max-conns")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("3"))
+	})
+})
+
+var _ = Describe("MapProperties", func() {
+	sut := di.MapProperties{"a": map[string]interface{}{"b": 1, "c": nil}}
+	It("should resolve nested keys", func() {
+		value, found := sut.Property("a.b")
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("1"))
+	})
+	It("should not resolve missing keys, nil values or maps", func() {
+		for _, key := range []string{"b", "a.c", "a", "a.b.c"} {
+			_, found := sut.Property(key)
+			Expect(found).To(BeFalse(), key)
+		}
+	})
+})
+
+var _ = Describe("PropertySources", func() {
+	It("should use the first match", func() {
+		sut := di.PropertySources{di.MapProperties{"a": "1"}, di.MapProperties{"a": "2", "b": "3"}}
+		value, _ := sut.Property("a")
+		Expect(value).To(Equal("1"))
+		value, _ = sut.Property("b")
+		Expect(value).To(Equal("3"))
+		_, found := sut.Property("c")
+		Expect(found).To(BeFalse())
+	})
+})
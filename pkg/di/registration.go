@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"runtime"
+	"sync"
+	"sync/atomic"
 
 	"github.com/pkg/errors"
 )
@@ -12,6 +14,13 @@ import (
 type Registration struct {
 	// FactoryFn is the factory function to be used to create a new instance
 	FactoryFn func() (interface{}, error)
+	// ResolvingFactoryFn is used instead of FactoryFn if set, the Parameters are resolved using the InstanceResolver.
+	// The optional cleanup function returned is called when the owning Scope is closed.
+	ResolvingFactoryFn func(resolver InstanceResolver) (instance interface{}, cleanup func() error, err error)
+	// Parameters are the parameter types of the factory function
+	Parameters []reflect.Type
+	// Results are the registrations for the fields of a result object returned by the factory (see Out)
+	Results Registrations
 	// Type is the target type of the registration
 	Type reflect.Type
 	// Qualifier is an optional qualifier for the component
@@ -22,8 +31,37 @@ type Registration struct {
 	Source string
 	// instance is being used to cache the wired instance, once the component is created
 	instance interface{}
+	// creator is the call (or the resolver of GetInstanceFrom) creating the instance, it is set until the instance has
+	// been wired. Other creators wait for it, while the creator itself gets the instance to resolve cycles of injected
+	// fields.
+	creator interface{}
+	// cleanup is the cleanup function returned by the factory for the instance
+	cleanup func() error
+	// createdSeq denotes the order in which the instances of all registrations have been created
+	createdSeq uint64
+	// selected is set atomically once the registration has been chosen to be injected by a Scope, since the
+	// registrations of a parent are shared by its children
+	selected uint32
+	// eager denotes if the registration is instantiated by Scope#InstantiateAll
+	eager bool
+	// module is the module which has installed the registration, see Scope#Install
+	module *Module
+	// owner is the registration of the result object, if this is one of its Results
+	owner *Registration
 }
 
+var (
+	// creations is the sequence for Registration.createdSeq
+	creations uint64
+	// creationMutex guards the instances and creators of all registrations, since the registrations of a parent are
+	// shared by its children, which may be used concurrently (e.g. a Scope per request)
+	creationMutex sync.Mutex
+	// creationCond is signaled whenever a creation has ended
+	creationCond = sync.NewCond(&creationMutex)
+	// creationsAwaited are the registrations the creators are waiting for, to detect cycles spanning several creators
+	creationsAwaited = map[interface{}]*Registration{}
+)
+
 func NewRegistration(val interface{}, skipCaller int) (*Registration, error) {
 	if val != nil {
 		tpe := reflect.TypeOf(val)
@@ -50,49 +88,269 @@ func newRegistration(fn func() (interface{}, error), tpe reflect.Type, skipCalle
 
 func newFactoryRegistration(val reflect.Value, skipCaller int) (*Registration, error) {
 	tpe := val.Type()
-	if paramCount := tpe.NumIn(); paramCount > 0 {
-		return nil, errors.Errorf("function should not have parameters, but got: %v", paramCount)
+	params := parametersOf(tpe, 0)
+	for idx, param := range params {
+		if idx == 0 && param == contextType {
+			continue
+		}
+		if !IsParameterObject(param) {
+			return nil, errors.Errorf(
+				"function should not have parameters other than a leading context.Context and parameter objects, but got: %v",
+				param,
+			)
+		}
+	}
+	if err := validateFactoryResults(tpe); err != nil {
+		return nil, err
 	}
 	returnCount := tpe.NumOut()
-	if returnCount > 0 && returnCount < 3 {
-		resultTpe := tpe.Out(0)
-		switch resultTpe.Kind() {
-		case reflect.Invalid, reflect.Uintptr, reflect.UnsafePointer, reflect.Func:
-			return nil, errors.Errorf("invalid factory result type: %v", resultTpe)
-		}
-		switch returnCount {
-		case 1:
-			return newRegistration(func() (interface{}, error) {
-				return val.Call(nil)[0].Interface(), nil
-			}, resultTpe, skipCaller+1), nil
-		case 2: // nolint:gomnd
-			if errParam := tpe.Out(1); !errParam.Implements(reflect.TypeOf((*error)(nil)).Elem()) {
-				return nil, errors.Errorf("second function return value should be error, but is: %v", errParam)
+	factoryFn := func(resolver InstanceResolver) (interface{}, func() error, error) {
+		args, err := resolveArguments(resolver, params)
+		if err != nil {
+			return nil, nil, err
+		}
+		results := val.Call(args)
+		result := results[0].Interface()
+		var cleanup func() error
+		if returnCount == 3 { // nolint:gomnd
+			cleanup = cleanupFn(results[1])
+		}
+		if returnCount > 1 {
+			if err := results[returnCount-1].Interface(); err != nil {
+				return result, cleanup, err.(error)
 			}
-			return newRegistration(func() (interface{}, error) {
-				results := val.Call(nil)
-				result := results[0].Interface()
-				if err := results[1].Interface(); err != nil {
-					return result, err.(error)
-				}
-				return result, nil
-			}, resultTpe, skipCaller+1), nil
 		}
+		return result, cleanup, nil
 	}
-	return nil, errors.Errorf("function should provide 1 or 2 return values, but has: %v", returnCount)
+	registration := newRegistration(nil, tpe.Out(0), skipCaller+1)
+	if len(params) > 0 || returnCount == 3 {
+		registration.ResolvingFactoryFn, registration.Parameters = factoryFn, params
+	} else {
+		registration.FactoryFn = func() (interface{}, error) {
+			result, _, err := factoryFn(nil)
+			return result, err
+		}
+	}
+	if IsResultObject(registration.Type) {
+		registration.Results = resultRegistrations(registration)
+	}
+	return registration, nil
 }
 
-// GetInstance returns the instance of the registration
+func validateFactoryResults(tpe reflect.Type) error {
+	returnCount := tpe.NumOut()
+	if returnCount == 0 || returnCount > 3 {
+		return errors.Errorf(
+			"function should provide 1 or 2 return values (or 3 including a cleanup function), but has: %v", returnCount,
+		)
+	}
+	resultTpe := tpe.Out(0)
+	switch resultTpe.Kind() {
+	case reflect.Invalid, reflect.Uintptr, reflect.UnsafePointer, reflect.Func:
+		return errors.Errorf("invalid factory result type: %v", resultTpe)
+	}
+	switch returnCount {
+	case 2: // nolint:gomnd
+		if errParam := tpe.Out(1); !errParam.Implements(errorType) {
+			return errors.Errorf("second function return value should be error, but is: %v", errParam)
+		}
+	case 3: // nolint:gomnd
+		if cleanupParam := tpe.Out(1); !isCleanupFn(cleanupParam) {
+			return errors.Errorf("second function return value should be func() or func() error, but is: %v", cleanupParam)
+		}
+		if errParam := tpe.Out(2); !errParam.Implements(errorType) {
+			return errors.Errorf("third function return value should be error, but is: %v", errParam)
+		}
+	}
+	return nil
+}
+
+func isCleanupFn(tpe reflect.Type) bool {
+	return tpe.Kind() == reflect.Func && tpe.NumIn() == 0 &&
+		(tpe.NumOut() == 0 || (tpe.NumOut() == 1 && tpe.Out(0) == errorType))
+}
+
+func cleanupFn(fn reflect.Value) func() error {
+	if fn.IsNil() {
+		return nil
+	}
+	return func() error {
+		if results := fn.Call(nil); len(results) > 0 && !results[0].IsNil() {
+			return results[0].Interface().(error)
+		}
+		return nil
+	}
+}
+
+// resultRegistrations creates a Registration for each exported field of the result object,
+// the qualifier is taken from the inject tag of the field
+func resultRegistrations(owner *Registration) (result Registrations) {
+	for i := 0; i < owner.Type.NumField(); i++ {
+		structFld := owner.Type.Field(i)
+		if !structFld.IsExported() || isMarker(structFld, outType) {
+			continue
+		}
+		name := structFld.Name
+		result = append(result, &Registration{
+			ResolvingFactoryFn: func(resolver InstanceResolver) (interface{}, func() error, error) {
+				instance, err := resolveRegistration(resolver, owner)
+				if err != nil {
+					return nil, nil, err
+				}
+				return reflect.ValueOf(instance).FieldByName(name).Interface(), nil, nil
+			},
+			Parameters: owner.Parameters,
+			Type:       structFld.Type,
+			Qualifier:  TagValueFrom(structFld.Tag.Get(TagKey)).Qualifier,
+			Source:     owner.Source,
+			owner:      owner,
+		})
+	}
+	return result
+}
+
+// GetInstance returns the instance of the registration, see GetInstanceFrom
 func (r *Registration) GetInstance() (result interface{}, first bool, err error) {
-	// If we have not created an instance for this registration, create it and wire it
-	if r.instance == nil {
-		first = true
-		r.instance, err = r.FactoryFn()
-		if err != nil {
-			return nil, first, errors.Wrapf(err, "could not create instance: %v", r)
+	return r.GetInstanceFrom(nil)
+}
+
+// GetInstanceFrom returns the instance of the registration, using the InstanceResolver for the factory parameters.
+// Concurrent calls wait for the instance being created by another resolver.
+func (r *Registration) GetInstanceFrom(resolver InstanceResolver) (result interface{}, first bool, err error) {
+	var creator interface{} = resolver
+	if resolver == nil {
+		creator = r
+	}
+	instance, begun, err := r.beginCreation(creator, nil)
+	if err != nil || !begun {
+		return instance, false, err
+	}
+	defer r.endCreation()
+	instance, err = r.create(resolver)
+	return instance, true, err
+}
+
+// beginCreation returns the instance, if it has been created. Otherwise, the creator begins the creation, after
+// waiting for another creator to end it. A dependency cycle is detected, if the creator has already begun the
+// creation without having created the instance, or if the other creator is waiting for the creator. wait is called
+// holding the creationMutex to wait for the next ended creation, it defaults to creationCond.Wait.
+func (r *Registration) beginCreation(creator interface{}, wait func()) (instance interface{}, begun bool, err error) {
+	if wait == nil {
+		wait = creationCond.Wait
+	}
+	creationMutex.Lock()
+	defer creationMutex.Unlock()
+	for r.creator != nil && r.creator != creator {
+		if awaits(r.creator, creator) {
+			return nil, false, errors.Errorf("dependency cycle detected for: %v", r)
+		}
+		creationsAwaited[creator] = r
+		wait()
+		delete(creationsAwaited, creator)
+	}
+	switch {
+	case r.instance != nil:
+		return r.instance, false, nil
+	case r.creator != nil:
+		return nil, false, errors.Errorf("dependency cycle detected for: %v", r)
+	}
+	r.creator = creator
+	return nil, true, nil
+}
+
+// awaits reports if the creator is waiting for the other creator, directly or through further creators
+func awaits(creator, other interface{}) bool {
+	for i := 0; i <= len(creationsAwaited); i++ {
+		awaited := creationsAwaited[creator]
+		if awaited == nil || awaited.creator == nil {
+			return false
+		}
+		if creator = awaited.creator; creator == other {
+			return true
+		}
+	}
+	return false
+}
+
+// endCreation ends the creation begun by beginCreation, even if the factory failed or panicked, and wakes up the
+// creators waiting for it
+func (r *Registration) endCreation() {
+	creationMutex.Lock()
+	defer creationMutex.Unlock()
+	r.creator = nil
+	creationCond.Broadcast()
+}
+
+// create calls the factory of the registration, whose creation must have been begun
+func (r *Registration) create(resolver InstanceResolver) (interface{}, error) {
+	if resolver == nil && len(parameterDependencies(r.Parameters)) > 0 {
+		return nil, errors.Errorf("cannot resolve factory parameters without resolver: %v", r)
+	}
+	instance, cleanup, err := r.createInstance(resolver)
+	return r.finishCreation(instance, cleanup, err)
+}
+
+// finishCreation caches the instance created by the factory, unless the factory failed
+func (r *Registration) finishCreation(instance interface{}, cleanup func() error, err error) (interface{}, error) {
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not create instance: %v", r)
+	}
+	creationMutex.Lock()
+	defer creationMutex.Unlock()
+	r.instance, r.cleanup = instance, cleanup
+	r.createdSeq = atomic.AddUint64(&creations, 1)
+	return instance, nil
+}
+
+// markSelected marks the registration as chosen to be injected, see Scope#Unused
+func (r *Registration) markSelected() {
+	atomic.StoreUint32(&r.selected, 1)
+}
+
+func (r *Registration) isSelected() bool {
+	return atomic.LoadUint32(&r.selected) == 1
+}
+
+func (r *Registration) createInstance(resolver InstanceResolver) (interface{}, func() error, error) {
+	if r.ResolvingFactoryFn != nil {
+		return r.ResolvingFactoryFn(resolver)
+	}
+	instance, err := r.FactoryFn()
+	return instance, nil, err
+}
+
+// close calls the cleanup function of the instance once
+func (r *Registration) close() error {
+	cleanup := r.cleanup
+	r.cleanup = nil
+	if cleanup == nil {
+		return nil
+	}
+	return cleanup()
+}
+
+// Clone creates a copy of the registration without the created instance
+func (r *Registration) Clone() *Registration {
+	result := &Registration{
+		FactoryFn:          r.FactoryFn,
+		ResolvingFactoryFn: r.ResolvingFactoryFn,
+		Parameters:         r.Parameters,
+		Type:               r.Type,
+		Qualifier:          r.Qualifier,
+		Priority:           r.Priority,
+		Source:             r.Source,
+		eager:              r.eager,
+		module:             r.module,
+	}
+	if len(r.Results) > 0 {
+		// the results must resolve the instance of the cloned registration
+		result.Results = resultRegistrations(result)
+		for idx, reg := range r.Results {
+			result.Results[idx].Qualifier, result.Results[idx].Priority = reg.Qualifier, reg.Priority
+			result.Results[idx].module = reg.module
 		}
 	}
-	return r.instance, first, nil
+	return result
 }
 
 // WithQualifier sets the Registration#Qualifier for the registered component returning the same ptr as in the receiver
@@ -77,10 +77,69 @@ var _ = Describe("NewRegistration()", func() {
 			_, err := di.NewRegistration(func(a int) InterfaceA { return nil }, 0)
 			Expect(err).To(MatchError(ContainSubstring("function should not have parameters")))
 		})
+		It("should create a new registration with parameter objects", func() {
+			registration, err := di.NewRegistration(func(in InA) *ComponentA1 {
+				return &ComponentA1{A: in.A}
+			}, 0)
+			Expect(registration, err).To(BeAssignableToTypeOf(&di.Registration{}))
+			Expect(registration.Parameters).To(Equal([]reflect.Type{reflect.TypeOf(InA{})}))
+			instance, _, err := registration.GetInstanceFrom(testResolver{value: reflect.ValueOf(ValueA("a"))})
+			Expect(instance, err).To(Equal(&ComponentA1{A: "a"}))
+		})
+		It("should error on parameter objects without resolver", func() {
+			registration, err := di.NewRegistration(func(in InA) *ComponentA1 { return nil }, 0)
+			Expect(err).NotTo(HaveOccurred())
+			_, _, err = registration.GetInstance()
+			Expect(err).To(MatchError(ContainSubstring("cannot resolve factory parameters without resolver")))
+		})
+		It("should create registrations for result objects", func() {
+			registration, err := di.NewRegistration(func() OutAB {
+				return OutAB{A: "a", A2: "a2", B: "b"}
+			}, 0)
+			Expect(registration, err).To(BeAssignableToTypeOf(&di.Registration{}))
+			Expect(registration.Results).To(HaveLen(3))
+			for idx, expected := range []interface{}{ValueA("a"), ValueA("a2"), ValueB("b")} {
+				result := registration.Results[idx]
+				Expect(result.Source).To(Equal(registration.Source))
+				Expect(result.Type).To(Equal(reflect.TypeOf(expected)))
+				instance, _, err := result.GetInstance()
+				Expect(instance, err).To(Equal(expected))
+			}
+			Expect(registration.Results[1].Qualifier).To(Equal("a2"))
+		})
 		It("should error on invalid 1st return type", func() {
 			_, err := di.NewRegistration(func() func() { return nil }, 0)
 			Expect(err).To(MatchError(ContainSubstring("invalid factory result type")))
 		})
+		It("should create a new registration with cleanup", func() {
+			cleaned := false
+			registration, err := di.NewRegistration(func() (*ComponentA1, func(), error) {
+				return &ComponentA1{}, func() { cleaned = true }, nil
+			}, 0)
+			Expect(registration, err).To(BeAssignableToTypeOf(&di.Registration{}))
+			Expect(registration.Type).To(Equal(reflect.TypeOf(&ComponentA1{})))
+			instance, cleanup, err := registration.ResolvingFactoryFn(nil)
+			Expect(instance, err).To(BeAssignableToTypeOf(&ComponentA1{}))
+			Expect(cleanup()).To(Succeed())
+			Expect(cleaned).To(BeTrue())
+		})
+		It("should create a new registration with error returning cleanup", func() {
+			registration, err := di.NewRegistration(func() (*ComponentA1, func() error, error) {
+				return &ComponentA1{}, func() error { return errors.New("meh") }, nil
+			}, 0)
+			Expect(registration, err).To(BeAssignableToTypeOf(&di.Registration{}))
+			_, cleanup, err := registration.ResolvingFactoryFn(nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cleanup()).To(MatchError("meh"))
+		})
+		It("should error on invalid cleanup type", func() {
+			_, err := di.NewRegistration(func() (InterfaceA, func(int), error) { return nil, nil, nil }, 0)
+			Expect(err).To(MatchError(ContainSubstring("second function return value should be func() or func() error")))
+		})
+		It("should error on invalid 3rd return type", func() {
+			_, err := di.NewRegistration(func() (InterfaceA, func(), int) { return nil, nil, 0 }, 0)
+			Expect(err).To(MatchError(ContainSubstring("third function return value should be error")))
+		})
 		It("should error on invalid 2nd return type", func() {
 			_, err := di.NewRegistration(func() (InterfaceA, int) { return nil, 0 }, 0)
 			Expect(err).To(MatchError(ContainSubstring("second function return value should be error")))
@@ -109,6 +168,15 @@ var _ = Describe("Registration", func() {
 			Expect(instance, err).To(BeAssignableToTypeOf(&ComponentA1{}))
 			Expect(first).To(BeFalse())
 		})
+		It("should not cache the instance on error", func() {
+			sut.FactoryFn = func() (interface{}, error) {
+				return &ComponentA1{}, errors.New("meh")
+			}
+			_, _, err := sut.GetInstance()
+			Expect(err).To(HaveOccurred())
+			_, _, err = sut.GetInstance()
+			Expect(err).To(HaveOccurred())
+		})
 		It("should return error from factory", func() {
 			errMsg := "meh"
 			sut.FactoryFn = func() (interface{}, error) {
@@ -122,6 +190,34 @@ var _ = Describe("Registration", func() {
 			)))
 		})
 	})
+	Context("Clone()", func() {
+		It("should copy the registration without instance", func() {
+			_, _, err := sut.GetInstance()
+			Expect(err).NotTo(HaveOccurred())
+			clone := sut.WithQualifier("a").WithPriority(1).Clone()
+			Expect(clone).NotTo(BeIdenticalTo(sut))
+			Expect(clone.Qualifier).To(Equal("a"))
+			Expect(clone.Priority).To(Equal(1))
+			Expect(clone.Source).To(Equal(sut.Source))
+			_, first, err := clone.GetInstance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(BeTrue())
+		})
+		It("should clone the results", func() {
+			var err error
+			sut, err = di.NewRegistration(func() OutAB { return OutAB{A: "a"} }, 0)
+			Expect(err).NotTo(HaveOccurred())
+			sut.Results[0].WithQualifier("a")
+			clone := sut.Clone()
+			Expect(clone.Results).To(HaveLen(3))
+			Expect(clone.Results[0].Qualifier).To(Equal("a"))
+			_, _, err = clone.Results[0].GetInstance()
+			Expect(err).NotTo(HaveOccurred())
+			_, first, err := clone.GetInstance()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(first).To(BeFalse())
+		})
+	})
 	Context("WithQualifier()", func() {
 		It("should set the qualifier", func() {
 			qualifier := "meh"
@@ -60,6 +60,25 @@ func (r Registrations) ByPriority() Registrations {
 	return result
 }
 
+func (r Registrations) contains(registration *Registration) bool {
+	for _, reg := range r {
+		if reg == registration {
+			return true
+		}
+	}
+	return false
+}
+
+// shadow denotes if any of the registrations has the type and qualifier of the registration, see LookupChildFirst
+func (r Registrations) shadow(registration *Registration) bool {
+	for _, reg := range r {
+		if reg.Type == registration.Type && reg.Qualifier == registration.Qualifier {
+			return true
+		}
+	}
+	return false
+}
+
 func (r Registrations) filter(f func(reg *Registration) bool) (result Registrations) {
 	for _, reg := range r {
 		if f(reg) {
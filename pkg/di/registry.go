@@ -0,0 +1,130 @@
+package di
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// GlobalRegistry is the registry used by Provide and Provided
+var GlobalRegistry = &Registry{}
+
+// Registry collects registrations by name, e.g. provided by packages from their init functions. The registrations are
+// not used until they are explicitly installed in a scope, see Registry#Modules.
+type Registry struct {
+	mutex         sync.Mutex
+	registrations map[string]Registrations
+	modules       map[string]*Module
+}
+
+// Provide registers a component or factory func under the name in the GlobalRegistry and panics on error. The returned
+// registration (e.g. its qualifier or priority) is copied to the scopes the name is installed in.
+func Provide(name string, valOrFunc interface{}) *Registration {
+	return GlobalRegistry.doProvide(name, valOrFunc, 1)
+}
+
+// Provided returns the modules of the names provided to the GlobalRegistry, see Registry#Modules
+func Provided(names ...string) []*Module {
+	return GlobalRegistry.Modules(names...)
+}
+
+// Provide registers a component or factory func under the name and panics on error, see the global Provide
+func (r *Registry) Provide(name string, valOrFunc interface{}) *Registration {
+	return r.doProvide(name, valOrFunc, 1)
+}
+
+func (r *Registry) doProvide(name string, valOrFunc interface{}, skipCaller int) *Registration {
+	registration, err := NewRegistration(valOrFunc, skipCaller+1)
+	if err != nil {
+		panic(errors.Wrapf(err, "could not provide %v", name))
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if r.registrations == nil {
+		r.registrations = map[string]Registrations{}
+	}
+	r.registrations[name] = append(r.registrations[name], registration)
+	if module, found := r.modules[name]; found {
+		// the module is kept, so that Scope#Install recognizes it as installed
+		module.Exports = r.exports(name)
+	}
+	return registration
+}
+
+// Names returns the sorted names registrations have been provided for
+func (r *Registry) Names() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result := make([]string, 0, len(r.registrations))
+	for name := range r.registrations {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// Registrations returns a copy of the registrations provided for the name
+func (r *Registry) Registrations(name string) Registrations {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return append(Registrations(nil), r.registrations[name]...)
+}
+
+// Modules returns the module per name (all names if none are given) to be installed by Scope#Install, registering clones
+// of the registrations provided for the name. All of their types are exported. Installing a name without any
+// registrations fails. Kindly note: registrations provided after the module has been installed in a scope are not
+// registered in that scope.
+func (r *Registry) Modules(names ...string) []*Module {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+	result := make([]*Module, len(names))
+	for idx, name := range names {
+		result[idx] = r.module(name)
+	}
+	return result
+}
+
+func (r *Registry) module(name string) *Module {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	if module, found := r.modules[name]; found {
+		return module
+	}
+	module := &Module{
+		Name:    name,
+		Exports: r.exports(name),
+		Register: func(scope *Scope) error {
+			registrations := r.Registrations(name)
+			if len(registrations) == 0 {
+				return errors.Errorf("no registrations provided for: %v", name)
+			}
+			for _, reg := range registrations {
+				scope.insert(len(scope.registrations), reg.Clone())
+			}
+			return nil
+		},
+	}
+	if r.modules == nil {
+		r.modules = map[string]*Module{}
+	}
+	r.modules[name] = module
+	return module
+}
+
+// exports returns the types of the registrations provided for the name, the caller must hold the mutex
+func (r *Registry) exports(name string) []reflect.Type {
+	var result []reflect.Type
+	seen := map[reflect.Type]bool{}
+	for _, reg := range r.registrations[name] {
+		for _, exported := range append(Registrations{reg}, reg.Results...) {
+			if !seen[exported.Type] {
+				seen[exported.Type] = true
+				result = append(result, exported.Type)
+			}
+		}
+	}
+	return result
+}
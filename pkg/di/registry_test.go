@@ -0,0 +1,76 @@
+package di_test
+
+import (
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var providedA = di.Provide("registry_test", ValueA("provided"))
+
+var _ = Describe("Registry", func() {
+	var sut *di.Registry
+	var scope *di.Scope
+	BeforeEach(func() {
+		sut = &di.Registry{}
+		scope = &di.Scope{}
+	})
+	It("should provide to the global registry", func() {
+		Expect(di.GlobalRegistry.Names()).To(ContainElement("registry_test"))
+		Expect(providedA.Source).To(MatchRegexp(`registry_test\.go:\d+$`))
+		scope.MustInstall(di.Provided("registry_test")...)
+		Expect(scope.MustInvoke(func(a ValueA) ValueA { return a })[0].Interface()).To(Equal(ValueA("provided")))
+	})
+	It("should install clones of the provided registrations by name", func() {
+		sut.Provide("b", ValueB("b"))
+		sut.Provide("a", func() *ComponentA1 { return &ComponentA1{} }).Qualifier = "a1"
+		sut.Provide("a", ValueA("a")).Priority = 1
+		Expect(sut.Names()).To(Equal([]string{"a", "b"}))
+		Expect(sut.Registrations("a")).To(HaveLen(2))
+		modules := sut.Modules("a")
+		Expect(modules).To(HaveLen(1))
+		Expect(modules[0].Name).To(Equal("a"))
+		Expect(modules[0].Exports).To(ConsistOf(reflect.TypeOf(&ComponentA1{}), reflect.TypeOf(ValueA(""))))
+		scope.MustInstall(modules...)
+		scope.MustInstall(sut.Modules("a")...)
+		Expect(scope.Registrations()).To(HaveLen(2))
+		Expect(scope.Registrations()[0]).NotTo(BeIdenticalTo(sut.Registrations("a")[0]))
+		Expect(scope.Registrations()[0].Qualifier).To(Equal("a1"))
+		Expect(scope.Registrations()[0].Source).To(ContainSubstring("registry_test.go"))
+		Expect(scope.Registrations()[1].Priority).To(Equal(1))
+		target := &struct {
+			A InterfaceA `inject:"qualifier=a1"`
+		}{}
+		scope.MustWire(target)
+		Expect(target.A.GetA()).To(Equal("a"))
+	})
+	It("should keep the module of a name when providing to it", func() {
+		sut.Provide("a", ValueA("a"))
+		module := sut.Modules("a")[0]
+		scope.MustInstall(module)
+		sut.Provide("a", ValueB("b"))
+		Expect(sut.Modules("a")[0]).To(BeIdenticalTo(module))
+		Expect(module.Exports).To(ConsistOf(reflect.TypeOf(ValueA("")), reflect.TypeOf(ValueB(""))))
+		scope.MustInstall(sut.Modules("a")...)
+		Expect(scope.Registrations()).To(HaveLen(1))
+		Expect(scope.MustInvoke(func(a ValueA) ValueA { return a })[0].Interface()).To(Equal(ValueA("a")))
+		other := &di.Scope{}
+		other.MustInstall(sut.Modules("a")...)
+		Expect(other.Registrations()).To(HaveLen(2))
+	})
+	It("should install all names", func() {
+		sut.Provide("a", ValueA("a"))
+		sut.Provide("b", ValueB("b"))
+		scope.MustInstall(sut.Modules()...)
+		Expect(scope.Registrations()).To(HaveLen(2))
+	})
+	It("should error on names without registrations", func() {
+		Expect(scope.Install(sut.Modules("missing")...)).
+			To(MatchError("could not install module missing: no registrations provided for: missing"))
+	})
+	It("should panic on invalid components", func() {
+		Expect(func() { sut.Provide("a", nil) }).To(Panic())
+	})
+})
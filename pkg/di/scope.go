@@ -1,45 +1,127 @@
 package di
 
 import (
+	"context"
+	"io"
 	"reflect"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
-var _ InstanceResolver = &Scope{}
+var (
+	_ InstanceResolver     = &Scope{}
+	_ registrationResolver = &Scope{}
+	_ io.Closer            = &Scope{}
+)
 
 // Scope is a scope for Registrations which is used to register and wire dependencies
 type Scope struct {
 	// Parent is the optional parent scope
-	Parent        *Scope
+	Parent *Scope
+	// Properties is the optional PropertySource for RegisterConfig, falls back to the parent's or EnvProperties
+	Properties PropertySource
+	// InjectUnexported enables the injection into unexported fields with an inject tag for targets wired in this scope.
+	// Kindly note: the fields are set using unsafe, bypassing the encapsulation of the target types.
+	InjectUnexported bool
+	// InjectMethods enables calling the inject methods (see IsInjectMethod) of targets wired in this scope
+	InjectMethods bool
+	// Lookup is the LookupStrategy for the registrations of this scope and its parents, LookupMerge by default
+	Lookup LookupStrategy
+	// Observers receive the events of this scope
+	Observers Observers
+	// Parallelism is the number of workers calling the factories concurrently in InstantiateAll, the registrations are
+	// instantiated sequentially if less than 2. Kindly note: the scope must not be used otherwise while instantiating.
+	Parallelism int
+	// ExplainErrors adds the explanation of the resolution (see Explain) to errors for missing or ambiguous candidates
+	ExplainErrors bool
+	// Tracer is the optional Tracer for spans of factory calls and wiring, nested according to the dependency chain
+	Tracer        Tracer
 	registrations Registrations
+	// retired are removed registrations with created instances to be cleaned up on Close
+	retired Registrations
+	// stats are the recorded statistics of the registrations owned by this scope
+	stats      map[*Registration]*RegistrationStats
+	statsMutex sync.Mutex
+	// installed are the modules installed in this scope, installing is the module currently being installed
+	installed  map[*Module]bool
+	installing *Module
+}
+
+// NewChild creates a new child scope with the receiver as parent, inheriting its settings.
+// The child uses LookupChildFirst by default: its registrations shadow the parent's registrations for the same type and
+// qualifier, regardless of the priority, e.g. a child registration for a type overrides the parent's instead of being
+// ambiguous. The parent's registrations are created and wired in the parent, they never get the child's registrations.
+func (s *Scope) NewChild(opts ...ScopeOption) *Scope {
+	child := &Scope{
+		Parent:           s,
+		InjectUnexported: s.InjectUnexported,
+		InjectMethods:    s.InjectMethods,
+		Lookup:           LookupChildFirst,
+		Parallelism:      s.Parallelism,
+		ExplainErrors:    s.ExplainErrors,
+		Observers:        append(Observers(nil), s.Observers...),
+		Tracer:           s.Tracer,
+	}
+	return child.Apply(opts...)
 }
 
 func (s *Scope) ResolveInstance(tpe reflect.Type, tag TagValue) (reflect.Value, error) {
+	return s.resolveInstance(&call{}, tpe, tag)
+}
+
+func (s *Scope) resolveInstance(c *call, tpe reflect.Type, tag TagValue) (reflect.Value, error) {
+	start := time.Now()
+	resolution := Resolution{Type: tpe, Tag: tag}
+	result, err := s.resolve(c, &resolution)
+	resolution.Duration, resolution.Err = time.Since(start), err
+	s.Observers.Resolved(resolution)
+	return result, err
+}
+
+func (s *Scope) resolve(c *call, resolution *Resolution) (reflect.Value, error) {
 	nilValue := reflect.ValueOf(nil)
+	tpe, tag := resolution.Type, resolution.Tag
 	identifier := tpe.String()
 	if len(tag.Qualifier) > 0 {
 		identifier += " with qualifier " + tag.Qualifier
 	}
 	switch tpe.Kind() {
 	case reflect.Array, reflect.Slice:
-		candidates, err := s.resolveInjections(tpe.Elem(), tag, identifier)
+		elemType, wrapped := injectedTypeOf(tpe.Elem())
+		candidates, err := s.resolveInjections(c, elemType, tag, identifier)
+		resolution.Candidates = candidates
 		if err != nil {
-			return nilValue, err
+			return nilValue, s.explained(c, err, tpe, tag)
 		}
 		result := reflect.MakeSlice(tpe, candidates.Len(), candidates.Len())
 		for idx, candidate := range candidates {
-			instance, err := s.wiredInstance(candidate)
+			resolution.Chosen = append(resolution.Chosen, candidate)
+			candidate.markSelected()
+			s.Observers.CandidateChosen(tpe, tag, candidate)
+			instance, err := s.wiredInstance(c, candidate)
 			if err != nil {
 				return nilValue, err
 			}
-			result.Index(idx).Set(reflect.ValueOf(instance))
+			s.recordStats(candidate, func(stats *RegistrationStats) { stats.injectedInto(c.consumer()) })
+			value := reflect.ValueOf(instance)
+			if wrapped {
+				value = wrapInjected(tpe.Elem(), value, candidate)
+			}
+			result.Index(idx).Set(value)
 		}
 		return result, nil
 	default:
-		candidates, err := s.resolveInjections(tpe, tag, identifier)
+		if s.resolvesToScope(tpe, tag, c.consumerModule()) {
+			return reflect.ValueOf(s), nil
+		}
+		valueType, wrapped := injectedTypeOf(tpe)
+		candidates, err := s.resolveInjections(c, valueType, tag, identifier)
+		resolution.Candidates = candidates
 		if err != nil {
-			return nilValue, err
+			return nilValue, s.explained(c, err, tpe, tag)
 		}
 		if len(candidates) == 0 {
 			return nilValue, nil
@@ -47,42 +129,215 @@ func (s *Scope) ResolveInstance(tpe reflect.Type, tag TagValue) (reflect.Value,
 		highestPriority := candidates[0].Priority
 		candidates = candidates.FilterPriority(highestPriority)
 		if len(candidates) > 1 {
-			return nilValue, errors.Errorf("multiple candidates with priority %v for %v:\n\t%v",
-				highestPriority, identifier, candidates)
+			return nilValue, s.explained(c, errors.Errorf("multiple candidates with priority %v for %v:\n\t%v",
+				highestPriority, identifier, candidates), tpe, tag)
 		}
-		instance, err := s.wiredInstance(candidates[0])
+		resolution.Chosen = candidates
+		candidates[0].markSelected()
+		s.Observers.CandidateChosen(tpe, tag, candidates[0])
+		instance, err := s.wiredInstance(c, candidates[0])
 		if err != nil {
 			return nilValue, err
 		}
+		s.recordStats(candidates[0], func(stats *RegistrationStats) { stats.injectedInto(c.consumer()) })
+		if wrapped {
+			return wrapInjected(tpe, reflect.ValueOf(instance), candidates[0]), nil
+		}
 		return reflect.ValueOf(instance), nil
 	}
 }
 
 // Register uses NewRegistration to register a component or factory func
 func (s *Scope) Register(valOrFunc interface{}) (*Registration, error) {
-	return s.doRegister(valOrFunc)
+	return s.doRegister(valOrFunc, 1)
 }
 
-func (s *Scope) doRegister(valOrFunc interface{}) (*Registration, error) {
-	registration, err := NewRegistration(valOrFunc, 2) // nolint:gomnd
+func (s *Scope) doRegister(valOrFunc interface{}, skipCaller int) (*Registration, error) {
+	registration, err := NewRegistration(valOrFunc, skipCaller+1)
 	if err != nil {
 		return nil, err
 	}
-	s.registrations = append(s.registrations, registration)
+	s.insert(len(s.registrations), registration)
 	return registration, nil
 }
 
 // MustRegister works like, Register but panics on error
 func (s *Scope) MustRegister(valOrFunc interface{}) *Registration {
-	result, err := s.doRegister(valOrFunc)
+	result, err := s.doRegister(valOrFunc, 1)
+	s.panicOnErr(err)
+	return result
+}
+
+// Registrations returns a copy of the registrations of this scope (without the parents' registrations)
+func (s *Scope) Registrations() Registrations {
+	result := make(Registrations, len(s.registrations))
+	copy(result, s.registrations)
+	return result
+}
+
+// Candidates returns the registrations of this scope and its parents (see Lookup) coercible to the type with the tag's
+// qualifier ordered by priority
+func (s *Scope) Candidates(tpe reflect.Type, tag TagValue) Registrations {
+	return s.lookupVisibleCandidates(tpe, tag, nil).ByPriority()
+}
+
+// Clone creates a copy of the scope with the same parent and settings, the registrations are cloned without their
+// created instances (see Registration#Clone)
+func (s *Scope) Clone() *Scope {
+	result := &Scope{
+		Parent:           s.Parent,
+		Properties:       s.Properties,
+		InjectUnexported: s.InjectUnexported,
+		InjectMethods:    s.InjectMethods,
+		Lookup:           s.Lookup,
+		Parallelism:      s.Parallelism,
+		ExplainErrors:    s.ExplainErrors,
+		Observers:        append(Observers(nil), s.Observers...),
+		Tracer:           s.Tracer,
+	}
+	for module := range s.installed {
+		if result.installed == nil {
+			result.installed = map[*Module]bool{}
+		}
+		result.installed[module] = true
+	}
+	for _, reg := range s.registrations {
+		if !s.isResult(reg) {
+			result.insert(len(result.registrations), reg.Clone())
+		}
+	}
+	return result
+}
+
+// Unregister removes the registration (including the registrations of its result object) from the scope.
+// Instances already created are not affected: they stay injected where they have been injected to before and their
+// cleanup functions are still called on Close.
+func (s *Scope) Unregister(registration *Registration) error {
+	_, err := s.remove(registration)
+	return err
+}
+
+// Replace replaces the registration with a new one for the component or factory func (see Unregister for the rules
+// of instances already created). The new registration takes the qualifier, priority and position of the old one.
+func (s *Scope) Replace(old *Registration, valOrFunc interface{}) (*Registration, error) {
+	registration, err := NewRegistration(valOrFunc, 1)
+	if err != nil {
+		return nil, err
+	}
+	idx, err := s.remove(old)
+	if err != nil {
+		return nil, err
+	}
+	registration.Qualifier, registration.Priority = old.Qualifier, old.Priority
+	s.insert(idx, registration)
+	return registration, nil
+}
+
+// Override replaces the registrations of this scope for the type and qualifier with a new one for the component or
+// factory func, which must be coercible to the type (see Unregister for the rules of instances already created).
+// The registrations of the type itself are replaced, otherwise the registration which would be resolved for the type,
+// e.g. the implementation of an interface. Other registrations coercible to the type, e.g. for io.Closer, are kept,
+// but the priority of the new registration is raised above theirs to be resolved for the type.
+// Kindly note: registrations of parent scopes are only overridden depending on the Lookup, e.g. for NewChild.
+func (s *Scope) Override(tpe reflect.Type, qualifier string, valOrFunc interface{}) (*Registration, error) {
+	registration, err := NewRegistration(valOrFunc, 1)
+	if err != nil {
+		return nil, err
+	}
+	if !isCoercible(tpe, registration.Type) {
+		return nil, errNotCoercible(tpe, registration.Type)
+	}
+	registration.Qualifier = qualifier
+	coercible := s.registrations.FilterCoercible(tpe).FilterQualifier(qualifier)
+	overridden := coercible.filter(func(reg *Registration) bool { return reg.Type == tpe })
+	if resolved := coercible.ByPriority(); len(overridden) == 0 && len(resolved) > 0 &&
+		(len(resolved) == 1 || resolved[1].Priority != resolved[0].Priority) {
+		overridden = resolved[:1]
+	}
+	idx := len(s.registrations)
+	for _, reg := range coercible {
+		if overridden.contains(reg) {
+			if removedIdx, err := s.remove(reg); err == nil && removedIdx < idx {
+				idx = removedIdx
+			}
+		} else if reg.Priority <= registration.Priority {
+			registration.Priority = reg.Priority - 1
+		}
+	}
+	s.insert(idx, registration)
+	return registration, nil
+}
+
+// isResult checks if the registration is one of the results of another registration in this scope
+func (s *Scope) isResult(registration *Registration) bool {
+	for _, reg := range s.registrations {
+		if reg.Results.contains(registration) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Scope) insert(idx int, registration *Registration) {
+	inserted := append(Registrations{registration}, registration.Results...)
+	result := make(Registrations, 0, len(s.registrations)+len(inserted))
+	result = append(result, s.registrations[:idx]...)
+	result = append(result, inserted...)
+	s.registrations = append(result, s.registrations[idx:]...)
+	for _, reg := range inserted {
+		if s.installing != nil {
+			reg.module = s.installing
+		}
+		s.Observers.RegistrationAdded(reg)
+	}
+}
+
+func (s *Scope) remove(registration *Registration) (int, error) {
+	idx := -1
+	for i, reg := range s.registrations {
+		if reg == registration {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		return idx, errors.Errorf("registration not found in scope: %v", registration)
+	}
+	removed := append(Registrations{registration}, registration.Results...)
+	s.registrations = s.registrations.filter(func(reg *Registration) bool { return !removed.contains(reg) })
+	s.retired = append(s.retired, removed.filter(func(reg *Registration) bool { return reg.cleanup != nil })...)
+	return idx, nil
+}
+
+// RegisterConfig binds the struct ptr target to the properties with the prefix (see BindConfig) and registers it
+func (s *Scope) RegisterConfig(target interface{}, prefix string) (*Registration, error) {
+	return s.doRegisterConfig(target, prefix)
+}
+
+func (s *Scope) doRegisterConfig(target interface{}, prefix string) (*Registration, error) {
+	if err := BindConfig(target, prefix, s.properties()); err != nil {
+		return nil, errors.Wrapf(err, "could not bind config with prefix: %v", prefix)
+	}
+	return s.doRegister(target, 2) // nolint:gomnd
+}
+
+// MustRegisterConfig works like RegisterConfig, but panics on error
+func (s *Scope) MustRegisterConfig(target interface{}, prefix string) *Registration {
+	result, err := s.doRegisterConfig(target, prefix)
 	s.panicOnErr(err)
 	return result
 }
 
 // Wire wires the targets and all dependencies
 func (s *Scope) Wire(targets ...interface{}) error {
+	return s.wire(&call{}, targets...)
+}
+
+func (s *Scope) wire(c *call, targets ...interface{}) error {
 	for _, target := range targets {
-		if err := s.wireSingle(target); err != nil {
+		err := s.tracedWire(c, target, nil,
+			TraceAttribute{Key: TraceAttributeType, Value: reflect.TypeOf(target).String()})
+		if err != nil {
 			return err
 		}
 	}
@@ -94,45 +349,220 @@ func (s *Scope) MustWire(targets ...interface{}) {
 	s.panicOnErr(s.Wire(targets...))
 }
 
+// Invoke calls the function fn with its parameters resolved from the scope and returns its results.
+// Parameters may be parameter objects (see IsParameterObject) to use qualifiers or optional dependencies.
+// If the last result of fn is a non-nil error, it is returned as well.
+func (s *Scope) Invoke(fn interface{}) ([]reflect.Value, error) {
+	fnVal := reflect.ValueOf(fn)
+	if fnVal.Kind() != reflect.Func || fnVal.IsNil() {
+		return nil, errors.Errorf("expected a function, but got: %v", reflect.TypeOf(fn))
+	}
+	fnType := fnVal.Type()
+	if fnType.IsVariadic() {
+		return nil, errors.Errorf("function must not be variadic: %v", fnType)
+	}
+	args, err := s.invokeArguments(&call{}, fnType)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not invoke: %v", fnType)
+	}
+	results := fnVal.Call(args)
+	if outCount := len(results); outCount > 0 && fnType.Out(outCount-1) == errorType && !results[outCount-1].IsNil() {
+		return results, results[outCount-1].Interface().(error)
+	}
+	return results, nil
+}
+
+func (s *Scope) invokeArguments(c *call, fnType reflect.Type) ([]reflect.Value, error) {
+	defer c.consuming(fnType, nil)()
+	return resolveArguments(&callResolver{scope: s, call: c}, parametersOf(fnType, 0))
+}
+
+// MustInvoke works like Invoke, but panics in case of error
+func (s *Scope) MustInvoke(fn interface{}) []reflect.Value {
+	results, err := s.Invoke(fn)
+	s.panicOnErr(err)
+	return results
+}
+
+// Close calls the cleanup functions returned by the factories of this scope's registrations in reverse creation order
+func (s *Scope) Close() error {
+	return closeRegistrations(append(s.registrations.filter(func(reg *Registration) bool {
+		return reg.cleanup != nil
+	}), s.retired...))
+}
+
+// closeRegistrations calls the cleanup functions of the registrations in reverse creation order
+func closeRegistrations(created Registrations) error {
+	sort.Slice(created, func(i, j int) bool { return created[i].createdSeq > created[j].createdSeq })
+	var errs Errors
+	for _, reg := range created {
+		if err := reg.close(); err != nil {
+			errs = append(errs, errors.Wrapf(err, "could not clean up: %v", reg))
+		}
+	}
+	return errs.errOrNil()
+}
+
 func (s *Scope) panicOnErr(err error) {
 	if err != nil {
 		panic(err)
 	}
 }
 
-func (s *Scope) wireSingle(target interface{}) error {
-	injectable, err := InjectableFrom(reflect.TypeOf(target))
+func (s *Scope) properties() PropertySource {
+	switch {
+	case s.Properties != nil:
+		return s.Properties
+	case s.Parent != nil:
+		return s.Parent.properties()
+	default:
+		return EnvProperties{}
+	}
+}
+
+// tracedWire wires the target within a span, see wireSingle
+func (s *Scope) tracedWire(c *call, target interface{}, module *Module, attributes ...TraceAttribute) (err error) {
+	endSpan := s.startSpan(c, SpanNameWire, attributes...)
+	defer func() { endSpan(err) }()
+	return s.wireSingle(c, target, module)
+}
+
+// wireSingle wires the target, which is a component of the module (nil for targets outside of modules)
+func (s *Scope) wireSingle(c *call, target interface{}, module *Module) error {
+	injectable, err := injectableFrom(reflect.TypeOf(target), s.InjectUnexported, s.InjectMethods)
 	if err != nil {
 		return err
 	}
-	return injectable.Apply(reflect.ValueOf(target), s)
+	defer c.consuming(reflect.TypeOf(target), module)()
+	return injectable.Apply(reflect.ValueOf(target), &callResolver{scope: s, call: c})
 }
 
-func (s *Scope) wiredInstance(candidate *Registration) (interface{}, error) {
-	instance, created, err := candidate.GetInstance()
+// wiredInstance returns the instance of the candidate, creating and wiring it in the scope owning the candidate, so
+// that the dependencies of a parent's registration are never resolved from the child it has been resolved for
+func (s *Scope) wiredInstance(c *call, candidate *Registration) (interface{}, error) {
+	if owner := s.ownerOf(candidate); owner != s {
+		return owner.wiredInstance(c, candidate)
+	}
+	instance, creating, err := candidate.beginCreation(c, c.wait())
+	if err != nil || !creating {
+		return instance, err
+	}
+	// the creation ends once the instance has been wired, so that concurrent scopes never get unwired instances
+	defer candidate.endCreation()
+	if err := c.canceled(); err != nil {
+		return nil, &CanceledError{Registration: candidate, Err: err}
+	}
+	instance, err = s.instanceOf(c, candidate)
 	if err != nil {
-		return nil, err
+		return nil, canceled(c.ctx, candidate, err)
 	}
-	if !created || reflect.TypeOf(instance).Kind() != reflect.Ptr {
-		return instance, nil
+	return instance, s.wireCreated(c, candidate, instance)
+}
+
+func (s *Scope) resolveRegistration(registration *Registration) (interface{}, error) {
+	return s.wiredInstance(&call{}, registration)
+}
+
+// instanceOf calls the factory of the candidate. The state of the call is restored, even if the factory panics.
+func (s *Scope) instanceOf(c *call, candidate *Registration) (instance interface{}, err error) {
+	s.Observers.FactoryStarted(candidate)
+	endSpan := s.startSpan(c, SpanNameFactory, traceAttributesOf(candidate)...)
+	stop, panicked := c.timing(), true
+	defer func() {
+		if panicked {
+			err = errFactoryPanicked(candidate)
+		}
+		endSpan(err)
+		total, self := stop()
+		s.factoryFinished(candidate, total, self, err)
+	}()
+	defer c.consuming(candidate.Type, candidate.module)()
+	instance, err = candidate.create(&callResolver{scope: s, call: c})
+	panicked = false
+	return instance, err
+}
+
+// factoryFinished records the self time and notifies the observers of the total time of the factory call
+func (s *Scope) factoryFinished(candidate *Registration, total, self time.Duration, err error) {
+	s.recordStats(candidate, func(stats *RegistrationStats) { stats.FactoryDuration = self })
+	s.Observers.FactoryFinished(candidate, total, err)
+}
+
+// wireCreated wires the instance created for the candidate, if it is a ptr
+func (s *Scope) wireCreated(c *call, candidate *Registration, instance interface{}) error {
+	if instance == nil || reflect.TypeOf(instance).Kind() != reflect.Ptr {
+		return nil
 	}
-	return instance, s.wireSingle(instance)
+	stop := c.timing()
+	err := s.tracedWire(c, instance, candidate.module, traceAttributesOf(candidate)...)
+	total, self := stop()
+	s.recordStats(candidate, func(stats *RegistrationStats) { stats.WiringDuration = self })
+	s.Observers.InstanceWired(candidate, total, err)
+	return err
 }
 
-func (s *Scope) resolveInjections(tpe reflect.Type, tag TagValue, identifier string) (Registrations, error) {
-	candidates := s.registrations.FilterCoercible(tpe)
-	if !tag.IsAllQualifier() {
-		candidates = candidates.FilterQualifier(tag.Qualifier)
+// startSpan starts a span as child of the current span of the call, if a Tracer is set, and returns the function to
+// end it
+func (s *Scope) startSpan(c *call, name string, attributes ...TraceAttribute) func(err error) {
+	if s.Tracer == nil {
+		return func(error) {}
 	}
-	if s.Parent != nil {
-		parentTag := *(&tag) // nolint:staticcheck
-		parentTag.Required = false
-		fromParent, _ := s.Parent.resolveInjections(tpe, parentTag, identifier)
-		candidates = append(candidates, fromParent...)
+	previous := c.ctx
+	parent := previous
+	if parent == nil {
+		parent = context.Background()
 	}
-	candidates = candidates.ByPriority()
+	ctx, span := s.Tracer.Start(parent, name, attributes...)
+	c.ctx = ctx
+	return func(err error) {
+		c.ctx = previous
+		span.End(err)
+	}
+}
+
+func (s *Scope) fieldInjected(target reflect.Value, injection Injection, value reflect.Value) {
+	s.Observers.FieldInjected(target, injection, value)
+}
+
+func (s *Scope) resolveInjections(c *call, tpe reflect.Type, tag TagValue, identifier string) (Registrations, error) {
+	candidates := s.lookupVisibleCandidates(tpe, tag, c.consumerModule()).ByPriority()
+	s.Observers.CandidatesFiltered(tpe, tag, candidates)
 	if tag.Required && len(candidates) == 0 {
 		return nil, errors.Errorf("no candidate found for: %v", identifier)
 	}
 	return candidates, nil
 }
+
+// lookupVisibleCandidates combines the candidates of the scope and its parents using the LookupStrategy, which are
+// visible to the components of the module (nil outside of modules, see Module)
+func (s *Scope) lookupVisibleCandidates(tpe reflect.Type, tag TagValue, module *Module) Registrations {
+	candidates := s.registrations.FilterCoercible(tpe).filter(func(reg *Registration) bool {
+		return reg.visibleTo(module, tpe)
+	})
+	if !tag.IsAllQualifier() {
+		candidates = candidates.FilterQualifier(tag.Qualifier)
+	}
+	strategy := s.lookupStrategy(tag)
+	if s.Parent == nil || strategy == LookupIsolated {
+		return candidates
+	}
+	fromParent := s.Parent.lookupVisibleCandidates(tpe, tag, module)
+	switch {
+	case strategy == LookupParentFirst && len(fromParent) > 0:
+		return fromParent
+	case strategy == LookupChildFirst:
+		fromParent = fromParent.filter(func(reg *Registration) bool { return !candidates.shadow(reg) })
+	}
+	// either merged, not shadowed or one of them is empty
+	return append(candidates, fromParent...)
+}
+
+func (s *Scope) lookupStrategy(tag TagValue) LookupStrategy {
+	if tag.Lookup != LookupDefault {
+		return tag.Lookup
+	}
+	if s.Lookup != LookupDefault {
+		return s.Lookup
+	}
+	return LookupMerge
+}
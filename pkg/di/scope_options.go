@@ -0,0 +1,73 @@
+package di
+
+// ScopeOption is an option to configure a Scope, see NewScope, Scope#Apply and Scope#NewChild
+type ScopeOption func(scope *Scope)
+
+// NewScope creates a new (root) scope configured by the options
+func NewScope(opts ...ScopeOption) *Scope {
+	return (&Scope{}).Apply(opts...)
+}
+
+// Apply configures the scope by the options returning the same ptr as in the receiver
+func (s *Scope) Apply(opts ...ScopeOption) *Scope {
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// WithProperties sets the Scope#Properties
+func WithProperties(properties PropertySource) ScopeOption {
+	return func(scope *Scope) {
+		scope.Properties = properties
+	}
+}
+
+// WithInjectMethods sets the Scope#InjectMethods
+func WithInjectMethods(enabled bool) ScopeOption {
+	return func(scope *Scope) {
+		scope.InjectMethods = enabled
+	}
+}
+
+// WithInjectUnexported sets the Scope#InjectUnexported
+func WithInjectUnexported(enabled bool) ScopeOption {
+	return func(scope *Scope) {
+		scope.InjectUnexported = enabled
+	}
+}
+
+// WithLookup sets the Scope#Lookup
+func WithLookup(strategy LookupStrategy) ScopeOption {
+	return func(scope *Scope) {
+		scope.Lookup = strategy
+	}
+}
+
+// WithObserver adds the observers to the Scope#Observers
+func WithObserver(observers ...Observer) ScopeOption {
+	return func(scope *Scope) {
+		scope.Observers = append(scope.Observers, observers...)
+	}
+}
+
+// WithTracer sets the Scope#Tracer
+func WithTracer(tracer Tracer) ScopeOption {
+	return func(scope *Scope) {
+		scope.Tracer = tracer
+	}
+}
+
+// WithExplainErrors sets the Scope#ExplainErrors
+func WithExplainErrors(explain bool) ScopeOption {
+	return func(scope *Scope) {
+		scope.ExplainErrors = explain
+	}
+}
+
+// WithParallelism sets the Scope#Parallelism
+func WithParallelism(workers int) ScopeOption {
+	return func(scope *Scope) {
+		scope.Parallelism = workers
+	}
+}
@@ -0,0 +1,50 @@
+package di_test
+
+import (
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ScopeOption", func() {
+	It("should apply the options to an existing scope", func() {
+		scope := &di.Scope{}
+		Expect(scope.Apply(di.WithExplainErrors(true), di.WithParallelism(2))).To(BeIdenticalTo(scope))
+		Expect(scope.ExplainErrors).To(BeTrue())
+		Expect(scope.Parallelism).To(Equal(2))
+	})
+	It("should set the properties", func() {
+		properties := di.MapProperties{}
+		scope := di.NewScope(di.WithProperties(properties))
+		Expect(scope.Properties).To(Equal(properties))
+	})
+	It("should set inject unexported", func() {
+		scope := di.NewScope(di.WithInjectUnexported(true))
+		Expect(scope.InjectUnexported).To(BeTrue())
+	})
+	It("should set inject methods", func() {
+		scope := di.NewScope(di.WithInjectMethods(true))
+		Expect(scope.InjectMethods).To(BeTrue())
+	})
+	It("should set the lookup strategy", func() {
+		scope := di.NewScope(di.WithLookup(di.LookupIsolated))
+		Expect(scope.Lookup).To(Equal(di.LookupIsolated))
+	})
+	It("should add the observers", func() {
+		scope := di.NewScope(di.WithObserver(di.NopObserver{}))
+		scope.Apply(di.WithObserver(di.NopObserver{}))
+		Expect(scope.Observers).To(HaveLen(2))
+	})
+	It("should set the tracer", func() {
+		scope := di.NewScope(di.WithTracer(nopTracer{}))
+		Expect(scope.Tracer).To(Equal(nopTracer{}))
+	})
+	It("should set explain errors", func() {
+		scope := di.NewScope(di.WithExplainErrors(true))
+		Expect(scope.ExplainErrors).To(BeTrue())
+	})
+	It("should set the parallelism", func() {
+		scope := di.NewScope(di.WithParallelism(8))
+		Expect(scope.Parallelism).To(Equal(8))
+	})
+})
@@ -3,6 +3,9 @@ package di_test
 import (
 	"errors"
 	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dbsystel/golang-runtime-di/pkg/di"
 	. "github.com/onsi/ginkgo/v2"
@@ -22,11 +25,252 @@ var _ = Describe("DefaultScope", func() {
 			)
 		})
 	})
+	Context("NewChild()", func() {
+		It("should create a child inheriting the settings", func() {
+			sut.InjectUnexported = true
+			child := sut.NewChild()
+			Expect(child.Parent).To(Equal(sut))
+			Expect(child.InjectUnexported).To(BeTrue())
+		})
+		It("should apply the options", func() {
+			properties := di.MapProperties{}
+			Expect(sut.NewChild(di.WithProperties(properties)).Properties).To(Equal(properties))
+		})
+		It("should shadow the parent's registrations", func() {
+			sut.MustRegister(ValueA("parent")).WithPriority(-1)
+			child := sut.NewChild()
+			child.MustRegister(ValueA("child"))
+			instance := &ComponentA1{}
+			child.MustWire(instance)
+			Expect(instance.A).To(BeEquivalentTo("child"))
+		})
+		It("should fall back to the parent's registrations", func() {
+			sut.MustRegister(ValueA("parent"))
+			child := sut.NewChild()
+			child.MustRegister(ValueA("child")).WithQualifier("a")
+			instance := &ComponentA1{}
+			child.MustWire(instance)
+			Expect(instance.A).To(BeEquivalentTo("parent"))
+		})
+	})
+	Context("Lookup", func() {
+		var child *di.Scope
+		BeforeEach(func() {
+			sut.Parent = &di.Scope{}
+			sut.Parent.MustRegister(ValueA("root"))
+			sut.MustRegister(ValueA("parent")).WithPriority(1)
+			child = &di.Scope{Parent: sut}
+			child.MustRegister(ValueA("child")).WithPriority(2)
+		})
+		resolve := func() []ValueA {
+			instance := &struct {
+				A []ValueA `inject:""`
+			}{}
+			child.MustWire(instance)
+			return instance.A
+		}
+		It("should merge by default", func() {
+			Expect(resolve()).To(Equal([]ValueA{"root", "parent", "child"}))
+		})
+		It("should use the nearest candidates of the same type and qualifier for child first", func() {
+			child.Lookup = di.LookupChildFirst
+			Expect(resolve()).To(Equal([]ValueA{"child"}))
+			child = &di.Scope{Parent: sut, Lookup: di.LookupChildFirst}
+			Expect(resolve()).To(Equal([]ValueA{"root", "parent"}))
+		})
+		It("should shadow the candidates of the parents by type and qualifier for child first", func() {
+			parent := &di.Scope{}
+			parent.MustRegister(ValueA("a")).WithQualifier("a")
+			parent.MustRegister(ValueA("b")).WithQualifier("b")
+			child = parent.NewChild()
+			child.MustRegister(ValueA("x")).WithQualifier("x")
+			child.MustRegister(ValueA("b2")).WithQualifier("b")
+			instance := &struct {
+				A []ValueA `inject:"qualifier=*"`
+			}{}
+			child.MustWire(instance)
+			Expect(instance.A).To(Equal([]ValueA{"x", "b2", "a"}))
+		})
+		It("should use the root candidates for parent first", func() {
+			child.Lookup = di.LookupParentFirst
+			sut.Lookup = di.LookupParentFirst
+			Expect(resolve()).To(Equal([]ValueA{"root"}))
+		})
+		It("should ignore the parents if isolated", func() {
+			child.Lookup = di.LookupIsolated
+			Expect(resolve()).To(Equal([]ValueA{"child"}))
+		})
+		It("should use the lookup of the tag", func() {
+			instance := &struct {
+				Local  ValueA `inject:"local"`
+				Parent ValueA `inject:"parent"`
+			}{}
+			child.MustWire(instance)
+			Expect(instance.Local).To(BeEquivalentTo("child"))
+			Expect(instance.Parent).To(BeEquivalentTo("root"))
+		})
+	})
 	Context("MustRegister()", func() {
 		It("should panic on error from registration", func() {
 			Expect(func() { sut.MustRegister(uintptr(0)) }).To(Panic())
 		})
 	})
+	Context("Registrations()", func() {
+		It("should return a copy of the registrations", func() {
+			reg := sut.MustRegister(ValueA("a"))
+			registrations := sut.Registrations()
+			Expect(registrations).To(Equal(di.Registrations{reg}))
+			registrations[0] = nil
+			Expect(sut.Registrations()).To(Equal(di.Registrations{reg}))
+		})
+	})
+	Context("Candidates()", func() {
+		It("should return the candidates by priority", func() {
+			sut.Parent = &di.Scope{}
+			parentReg := sut.Parent.MustRegister(ValueA("a")).WithPriority(-1)
+			reg := sut.MustRegister(ValueA("b"))
+			sut.MustRegister(ValueA("c")).WithQualifier("c")
+			Expect(sut.Candidates(reflect.TypeOf(ValueA("")), di.TagValue{})).To(Equal(di.Registrations{parentReg, reg}))
+		})
+	})
+	Context("Clone()", func() {
+		It("should clone settings and registrations", func() {
+			sut.Parent = &di.Scope{}
+			sut.Lookup = di.LookupIsolated
+			sut.MustRegister(func() *ComponentA2 { return &ComponentA2{} })
+			sut.MustRegister(func() OutAB { return OutAB{} })
+			original := &struct {
+				A *ComponentA2 `inject:""`
+			}{}
+			sut.MustWire(original)
+			clone := sut.Clone()
+			Expect(clone.Parent).To(Equal(sut.Parent))
+			Expect(clone.Lookup).To(Equal(di.LookupIsolated))
+			instance := &struct {
+				A *ComponentA2 `inject:""`
+				B ValueB       `inject:""`
+			}{}
+			clone.MustWire(instance)
+			Expect(instance.A).NotTo(BeIdenticalTo(original.A))
+		})
+	})
+	Context("Unregister()", func() {
+		It("should remove the registration and its results", func() {
+			reg := sut.MustRegister(func() OutAB { return OutAB{} })
+			Expect(sut.Unregister(reg)).To(Succeed())
+			Expect(sut.Wire(&ComponentA1{})).To(MatchError(ContainSubstring("no candidate found")))
+		})
+		It("should keep created instances and clean them up on close", func() {
+			cleaned := false
+			reg := sut.MustRegister(func() (ValueA, func(), error) { return "a", func() { cleaned = true }, nil })
+			instance := &ComponentA1{}
+			sut.MustWire(instance)
+			Expect(sut.Unregister(reg)).To(Succeed())
+			Expect(instance.A).To(BeEquivalentTo("a"))
+			Expect(sut.Close()).To(Succeed())
+			Expect(cleaned).To(BeTrue())
+		})
+		It("should error if not registered", func() {
+			reg, err := di.NewRegistration(ValueA("a"), 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sut.Unregister(reg)).To(MatchError(ContainSubstring("registration not found in scope")))
+		})
+	})
+	Context("Replace()", func() {
+		It("should replace the registration keeping qualifier and priority", func() {
+			old := sut.MustRegister(ValueA("a")).WithQualifier("a").WithPriority(-1)
+			sut.MustRegister(ValueA("b")).WithQualifier("a")
+			reg, err := sut.Replace(old, func() ValueA { return "c" })
+			Expect(reg, err).NotTo(BeNil())
+			Expect(reg.Qualifier).To(Equal("a"))
+			Expect(reg.Priority).To(Equal(-1))
+			Expect(reg.Source).To(ContainSubstring("scope_test.go:"))
+			instance := &ComponentA2{}
+			sut.MustWire(instance)
+			Expect(instance.A).To(BeEquivalentTo("c"))
+		})
+		It("should error if not registered", func() {
+			reg, err := di.NewRegistration(ValueA("a"), 0)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = sut.Replace(reg, ValueA("b"))
+			Expect(err).To(MatchError(ContainSubstring("registration not found in scope")))
+		})
+		It("should error on invalid component", func() {
+			_, err := sut.Replace(sut.MustRegister(ValueA("a")), nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid component type")))
+		})
+	})
+	Context("Override()", func() {
+		It("should replace the registrations of the type with the qualifier", func() {
+			sut.MustRegister(&ComponentA1{}).WithPriority(-1)
+			sut.MustRegister(func() InterfaceA { return &ComponentA1{} })
+			sut.MustRegister(&ComponentA1{}).WithQualifier("a")
+			reg, err := sut.Override(reflect.TypeOf((*InterfaceA)(nil)).Elem(), "", &ComponentA2{})
+			Expect(reg, err).NotTo(BeNil())
+			instance := &ComponentB1{}
+			sut.MustWire(instance)
+			Expect(instance.A).To(Equal(&ComponentA2{}))
+		})
+		It("should replace the registration resolved for the type", func() {
+			sut.MustRegister(&ComponentA1{})
+			reg, err := sut.Override(reflect.TypeOf((*InterfaceA)(nil)).Elem(), "", &ComponentA2{})
+			Expect(reg, err).NotTo(BeNil())
+			Expect(sut.Registrations()).To(Equal(di.Registrations{reg}))
+		})
+		It("should keep other registrations coercible to the type", func() {
+			a := sut.MustRegister(ValueA("a"))
+			b := sut.MustRegister(ValueB("b"))
+			emptyInterface := reflect.TypeOf((*interface{})(nil)).Elem()
+			reg, err := sut.Override(emptyInterface, "", ValueA("fake"))
+			Expect(reg, err).NotTo(BeNil())
+			Expect(sut.Registrations()).To(Equal(di.Registrations{a, b, reg}))
+			Expect(reg.Priority).To(Equal(-1))
+			instance, err := sut.ResolveInstance(emptyInterface, di.TagValue{})
+			Expect(instance.Interface(), err).To(Equal(ValueA("fake")))
+			Expect(sut.MustInvoke(func(a ValueA, b ValueB) string { return string(a) + string(b) })[0].String()).
+				To(Equal("fakeb"))
+		})
+		It("should error if not coercible", func() {
+			_, err := sut.Override(reflect.TypeOf((*InterfaceA)(nil)).Elem(), "", ValueA("a"))
+			Expect(err).To(MatchError(ContainSubstring("cannot coerce")))
+		})
+		It("should error on invalid component", func() {
+			_, err := sut.Override(reflect.TypeOf(ValueA("")), "", nil)
+			Expect(err).To(MatchError(ContainSubstring("invalid component type")))
+		})
+	})
+	Context("RegisterConfig()", func() {
+		It("should bind and register the config", func() {
+			sut.Properties = di.MapProperties{"database": map[string]interface{}{"host": "db"}}
+			reg, err := sut.RegisterConfig(&DBConfig{}, "database")
+			Expect(reg, err).NotTo(BeNil())
+			Expect(reg.Source).To(ContainSubstring("scope_test.go:"))
+			instance := &struct {
+				Config *DBConfig `inject:""`
+			}{}
+			sut.MustWire(instance)
+			Expect(instance.Config.Host).To(Equal("db"))
+			Expect(instance.Config.Port).To(BeEquivalentTo(5432))
+		})
+		It("should use the parent properties", func() {
+			sut.Parent = &di.Scope{Properties: di.MapProperties{"host": "db"}}
+			Expect(sut.RegisterConfig(&DBConfig{}, "")).NotTo(BeNil())
+		})
+		It("should error on missing properties", func() {
+			sut.Properties = di.MapProperties{}
+			_, err := sut.RegisterConfig(&DBConfig{}, "database")
+			Expect(err).To(MatchError(ContainSubstring("could not bind config with prefix: database")))
+		})
+	})
+	Context("MustRegisterConfig()", func() {
+		It("should panic on error", func() {
+			Expect(func() { sut.MustRegisterConfig(&DBConfig{}, "di_test_missing") }).To(Panic())
+		})
+		It("should register the source of the caller", func() {
+			sut.Properties = di.MapProperties{"host": "db"}
+			Expect(sut.MustRegisterConfig(&DBConfig{}, "").Source).To(ContainSubstring("scope_test.go:"))
+		})
+	})
 	Context("MustWire()", func() {
 		It("should wire an instance", func() {
 			sut.MustRegister(ValueA("a"))
@@ -51,6 +295,39 @@ var _ = Describe("DefaultScope", func() {
 			sut.MustWire(instance)
 			Expect(instance).To(Equal(&ComponentB1{A: &ComponentA2{A: "a"}}))
 		})
+		It("should call inject methods if enabled", func() {
+			sut.MustRegister(ValueA("a"))
+			sut.MustRegister(&ComponentA1{})
+			instance := &ComponentM{}
+			sut.MustWire(instance)
+			Expect(instance.a).To(BeNil())
+			sut.InjectMethods = true
+			sut.MustWire(instance)
+			Expect(instance.GetA()).To(Equal("a"))
+			Expect(sut.Wire(&InvalidMethodComponent{})).To(MatchError(ContainSubstring("invalid inject method")))
+		})
+		It("should inject unexported fields if enabled", func() {
+			sut.InjectUnexported = true
+			sut.MustRegister(&ComponentA2{})
+			instance := &UnexportedComponent{}
+			sut.MustWire(instance)
+			Expect(instance).To(Equal(&UnexportedComponent{a: &ComponentA2{}}))
+		})
+		It("should wire factories with parameter and result objects", func() {
+			sut.MustRegister(ValueA("a"))
+			sut.MustRegister(func(in InA) OutAB {
+				return OutAB{A: in.A + "1", A2: in.A + "2", B: "b"}
+			}).Results[0].WithQualifier("a1")
+			instance := &struct {
+				A1 ValueA `inject:"qualifier=a1"`
+				A2 ValueA `inject:"qualifier=a2"`
+				B  ValueB `inject:""`
+			}{}
+			sut.MustWire(instance)
+			Expect(instance.A1).To(BeEquivalentTo("a1"))
+			Expect(instance.A2).To(BeEquivalentTo("a2"))
+			Expect(instance.B).To(BeEquivalentTo("b"))
+		})
 		It("should wire all known", func() {
 			sut.MustRegister(ValueA("b")).WithQualifier("a")
 			sut.MustRegister(ValueA("a"))
@@ -66,6 +343,88 @@ var _ = Describe("DefaultScope", func() {
 			Expect(instance).To(Equal(&ComponentA2{}))
 		})
 	})
+	Context("Invoke()", func() {
+		It("should call the function with resolved parameters", func() {
+			sut.MustRegister(ValueA("a")).WithQualifier("a")
+			sut.MustRegister(ValueA("b"))
+			var invoked []interface{}
+			results, err := sut.Invoke(func(b ValueA, all []ValueA, params ParamsA) int {
+				invoked = []interface{}{b, all, params}
+				return 1
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(results).To(HaveLen(1))
+			Expect(results[0].Interface()).To(Equal(1))
+			Expect(invoked).To(Equal([]interface{}{
+				ValueA("b"),
+				[]ValueA{"b"},
+				ParamsA{A: "a", All: []ValueA{"b", "a"}},
+			}))
+		})
+		It("should return the error of the function", func() {
+			results, err := sut.Invoke(func() (int, error) { return 1, errors.New("meh") })
+			Expect(err).To(MatchError("meh"))
+			Expect(results).To(HaveLen(2))
+		})
+		It("should error if parameter cannot be resolved", func() {
+			_, err := sut.Invoke(func(ValueA) {})
+			Expect(err).To(MatchError(ContainSubstring("could not invoke")))
+		})
+		It("should error if parameter object cannot be resolved", func() {
+			_, err := sut.Invoke(func(ParamsA) {})
+			Expect(err).To(MatchError(ContainSubstring("could not resolve component for field: A")))
+		})
+		It("should error on invalid functions", func() {
+			_, err := sut.Invoke(ValueA("a"))
+			Expect(err).To(MatchError(ContainSubstring("expected a function")))
+			_, err = sut.Invoke(func(...ValueA) {})
+			Expect(err).To(MatchError(ContainSubstring("must not be variadic")))
+		})
+	})
+	Context("MustInvoke()", func() {
+		It("should panic on error", func() {
+			Expect(func() { sut.MustInvoke(nil) }).To(Panic())
+		})
+		It("should return the results", func() {
+			Expect(sut.MustInvoke(func() int { return 1 })[0].Interface()).To(Equal(1))
+		})
+	})
+	Context("Close()", func() {
+		It("should clean up in reverse creation order once", func() {
+			var cleaned []string
+			sut.MustRegister(func() (ValueA, func(), error) {
+				return "a", func() { cleaned = append(cleaned, "a") }, nil
+			})
+			sut.MustRegister(func(in InA) (InterfaceA, func() error, error) {
+				return &ComponentA1{}, func() error {
+					cleaned = append(cleaned, "component")
+					return nil
+				}, nil
+			})
+			sut.MustRegister(func() (ValueB, func(), error) {
+				return "b", func() { cleaned = append(cleaned, "b") }, nil
+			})
+			sut.MustWire(&ComponentB1{})
+			Expect(sut.Close()).To(Succeed())
+			Expect(cleaned).To(Equal([]string{"b", "component", "a"}))
+			Expect(sut.Close()).To(Succeed())
+			Expect(cleaned).To(HaveLen(3))
+		})
+		It("should return all cleanup errors", func() {
+			for _, val := range []ValueA{"a", "b"} {
+				errMsg := string(val)
+				sut.MustRegister(func() (ValueA, func() error, error) {
+					return "", func() error { return errors.New(errMsg) }, nil
+				}).WithQualifier(errMsg)
+			}
+			sut.MustWire(&AllValueA{})
+			Expect(sut.Close()).To(MatchError(And(
+				ContainSubstring("could not clean up"),
+				ContainSubstring(": b\n\t"),
+				HaveSuffix(": a"),
+			)))
+		})
+	})
 	Context("Wire()", func() {
 		It("should return error from factory", func() {
 			sut.MustRegister(func() (ValueA, error) {
@@ -76,6 +435,9 @@ var _ = Describe("DefaultScope", func() {
 		It("should error if invalid type", func() {
 			Expect(sut.Wire(ValueA(""))).To(HaveOccurred())
 		})
+		It("should error on unexported fields by default", func() {
+			Expect(sut.Wire(&UnexportedComponent{})).To(MatchError(ContainSubstring("field not exported")))
+		})
 		It("should error if dependency not found", func() {
 			Expect(sut.Wire(&ComponentA1{})).To(HaveOccurred())
 		})
@@ -88,6 +450,122 @@ var _ = Describe("DefaultScope", func() {
 			})
 			Expect(sut.Wire(&AllValueA{})).To(MatchError(ContainSubstring("meh")))
 		})
+		It("should error on dependency cycles", func() {
+			sut.MustRegister(func(in InCycle) InterfaceA { return &ComponentA1{} })
+			sut.MustRegister(func(in InA) InterfaceB { return &ComponentB1{} })
+			sut.MustRegister(func(in struct {
+				di.In
+				A InterfaceA `inject:""`
+			}) ValueA {
+				return ""
+			})
+			Expect(sut.Wire(&ComponentB1{})).To(MatchError(ContainSubstring("dependency cycle detected")))
+		})
+		It("should recover from panicking factories", func() {
+			observer := &recordingObserver{}
+			sut.Apply(di.WithObserver(observer))
+			regA := sut.MustRegister(ValueA("a"))
+			panics := true
+			sut.MustRegister(func() ValueB {
+				if panics {
+					panic("meh")
+				}
+				return "b"
+			})
+			Expect(func() { _, _ = sut.Invoke(func(a ValueA, b ValueB) {}) }).To(PanicWith("meh"))
+			Expect(observer.errs).To(ContainElement(MatchError(ContainSubstring("factory panicked"))))
+			panics = false
+			Expect(sut.MustInvoke(func(b ValueB) ValueB { return b })[0].Interface()).To(Equal(ValueB("b")))
+			_, err := sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+			Expect(err).NotTo(HaveOccurred())
+			for _, stats := range sut.Stats() {
+				if stats.Registration == regA {
+					Expect(stats.InjectedInto).To(HaveLen(1))
+				}
+			}
+		})
+		It("should wait for the instances created by concurrent child scopes", func() {
+			var calls int32
+			sut.MustRegister(func() ValueA {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return "a"
+			})
+			var wg sync.WaitGroup
+			for i := 0; i < 8; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					target := &ComponentA1{}
+					Expect(sut.NewChild().Wire(target)).To(Succeed())
+					Expect(target.A).To(BeEquivalentTo("a"))
+				}()
+			}
+			wg.Wait()
+			Expect(calls).To(BeEquivalentTo(1))
+		})
+		It("should wait for the instances created by concurrent calls on the same scope", func() {
+			var calls int32
+			sut.MustRegister(func() ValueA {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(20 * time.Millisecond)
+				return "a"
+			})
+			var wg sync.WaitGroup
+			for i := 0; i < 2; i++ {
+				wg.Add(1)
+				go func() {
+					defer GinkgoRecover()
+					defer wg.Done()
+					target := &ComponentA1{}
+					Expect(sut.Wire(target)).To(Succeed())
+					Expect(target.A).To(BeEquivalentTo("a"))
+				}()
+			}
+			wg.Wait()
+			Expect(calls).To(BeEquivalentTo(1))
+		})
+		It("should wire the registrations of the parent in the parent", func() {
+			sut.MustRegister(ValueA("parent"))
+			sut.MustRegister(func() *ComponentA1 { return &ComponentA1{} })
+			child := sut.NewChild()
+			child.MustRegister(ValueA("child"))
+			target := &struct {
+				C *ComponentA1 `inject:""`
+			}{}
+			Expect(child.Wire(target)).To(Succeed())
+			Expect(target.C.A).To(BeEquivalentTo("parent"))
+		})
+		It("should error on dependency cycles spanning concurrent child scopes", func() {
+			sut.MustRegister(func() SlowC { time.Sleep(10 * time.Millisecond); return "c" })
+			sut.MustRegister(func() SlowD { time.Sleep(10 * time.Millisecond); return "d" })
+			sut.MustRegister(func(in struct {
+				di.In
+				C SlowC `inject:""`
+				B SlowB `inject:""`
+			}) SlowA {
+				return "a"
+			})
+			sut.MustRegister(func(in struct {
+				di.In
+				D SlowD `inject:""`
+				A SlowA `inject:""`
+			}) SlowB {
+				return "b"
+			})
+			var wg sync.WaitGroup
+			for _, fn := range []interface{}{func(SlowA) {}, func(SlowB) {}} {
+				wg.Add(1)
+				go func(fn interface{}) {
+					defer GinkgoRecover()
+					defer wg.Done()
+					_, err := sut.NewChild().Invoke(fn)
+					Expect(err).To(MatchError(ContainSubstring("dependency cycle detected")))
+				}(fn)
+			}
+			wg.Wait()
+		})
 		It("should error on multiple candidates", func() {
 			sut.MustRegister(ValueA("a"))
 			sut.MustRegister(ValueA("b"))
@@ -0,0 +1,103 @@
+//go:build go1.21
+// +build go1.21
+
+package di
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	"time"
+)
+
+const (
+	SlogMessageResolved      = "di: resolved"
+	SlogMessageResolveFailed = "di: resolve failed"
+	SlogMessageFactoryFailed = "di: factory failed"
+	SlogMessageWireFailed    = "di: wiring failed"
+)
+
+var _ Observer = &SlogObserver{}
+
+// SlogObserver is an Observer logging the resolution decisions of a scope at debug level and failures at error level.
+// Kindly note: this observer is only available for go1.21 or later
+type SlogObserver struct {
+	NopObserver
+	// Logger is the logger to use, slog.Default() if nil
+	Logger *slog.Logger
+}
+
+// WithSlog adds a SlogObserver using the logger (slog.Default() if nil) to the Scope#Observers
+func WithSlog(logger *slog.Logger) ScopeOption {
+	return WithObserver(&SlogObserver{Logger: logger})
+}
+
+func (o *SlogObserver) Resolved(resolution Resolution) {
+	attrs := []slog.Attr{
+		slog.String("type", resolution.Type.String()),
+		slog.String("qualifier", resolution.Tag.Qualifier),
+		slog.Duration("elapsed", resolution.Duration),
+	}
+	if len(resolution.Chosen) > 0 {
+		attrs = append(attrs, slog.Any("chosen", slogRegistrations(resolution.Chosen)))
+	}
+	if rejected := resolution.Candidates.filter(func(reg *Registration) bool {
+		return !resolution.Chosen.contains(reg)
+	}); len(rejected) > 0 {
+		attrs = append(attrs, slog.Any("rejected", slogRegistrations(rejected)))
+	}
+	if resolution.Err != nil {
+		o.log(slog.LevelError, SlogMessageResolveFailed, append(attrs, slog.Any("error", resolution.Err))...)
+		return
+	}
+	o.log(slog.LevelDebug, SlogMessageResolved, attrs...)
+}
+
+func (o *SlogObserver) FactoryFinished(registration *Registration, duration time.Duration, err error) {
+	if err != nil {
+		o.log(slog.LevelError, SlogMessageFactoryFailed, slogRegistration(registration),
+			slog.Duration("elapsed", duration), slog.Any("error", err))
+	}
+}
+
+func (o *SlogObserver) InstanceWired(registration *Registration, duration time.Duration, err error) {
+	if err != nil {
+		o.log(slog.LevelError, SlogMessageWireFailed, slogRegistration(registration),
+			slog.Duration("elapsed", duration), slog.Any("error", err))
+	}
+}
+
+func (o *SlogObserver) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	logger := o.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ctx := context.Background()
+	if logger.Enabled(ctx, level) {
+		logger.LogAttrs(ctx, level, msg, attrs...)
+	}
+}
+
+func slogRegistration(registration *Registration) slog.Attr {
+	return slog.Group("registration", slogRegistrationAttrs(registration)...)
+}
+
+func slogRegistrationAttrs(registration *Registration) []any {
+	return []any{
+		slog.String("type", registration.Type.String()),
+		slog.String("qualifier", registration.Qualifier),
+		slog.Int("priority", registration.Priority),
+		slog.String("source", registration.Source),
+	}
+}
+
+// slogRegistrations logs registrations as a list of groups
+type slogRegistrations Registrations
+
+func (r slogRegistrations) LogValue() slog.Value {
+	attrs := make([]slog.Attr, len(r))
+	for idx, reg := range r {
+		attrs[idx] = slog.Group(strconv.Itoa(idx), slogRegistrationAttrs(reg)...)
+	}
+	return slog.GroupValue(attrs...)
+}
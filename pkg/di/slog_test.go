@@ -0,0 +1,56 @@
+//go:build go1.21
+// +build go1.21
+
+package di_test
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"reflect"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SlogObserver", func() {
+	var out *bytes.Buffer
+	var sut *di.Scope
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		sut = di.NewScope(di.WithSlog(slog.New(slog.NewTextHandler(out, &slog.HandlerOptions{Level: slog.LevelDebug}))))
+	})
+	It("should log the resolution decision", func() {
+		sut.MustRegister(ValueA("a")).WithQualifier("q")
+		sut.MustRegister(ValueA("b")).WithQualifier("q").WithPriority(1)
+		_, err := sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{Qualifier: "q"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(SatisfyAll(
+			ContainSubstring("level=DEBUG"),
+			ContainSubstring(`msg="di: resolved"`),
+			ContainSubstring("type=di_test.ValueA qualifier=q"),
+			ContainSubstring("chosen.0.type=di_test.ValueA chosen.0.qualifier=q chosen.0.priority=0"),
+			ContainSubstring("rejected.0.type=di_test.ValueA rejected.0.qualifier=q rejected.0.priority=1"),
+			ContainSubstring("slog_test.go"),
+			ContainSubstring("elapsed="),
+		))
+	})
+	It("should log failures", func() {
+		sut.MustRegister(func() (ValueA, error) { return "", errors.New("fail") })
+		_, err := sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+		Expect(err).To(HaveOccurred())
+		Expect(out.String()).To(SatisfyAll(
+			ContainSubstring(`level=ERROR msg="di: factory failed" registration.type=di_test.ValueA`),
+			ContainSubstring(`level=ERROR msg="di: resolve failed"`),
+		))
+	})
+	It("should not log the resolution if debug is disabled", func() {
+		sut.Observers = nil
+		sut.Apply(di.WithSlog(slog.New(slog.NewTextHandler(out, nil))))
+		sut.MustRegister(ValueA("a"))
+		_, err := sut.ResolveInstance(reflect.TypeOf(ValueA("")), di.TagValue{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(BeEmpty())
+	})
+})
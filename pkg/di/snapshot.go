@@ -0,0 +1,55 @@
+package di
+
+// Snapshot is the saved state of a Scope's registrations, installed modules and created instances, see Scope#Snapshot
+type Snapshot struct {
+	registrations Registrations
+	retired       Registrations
+	installed     map[*Module]bool
+	states        map[*Registration]registrationState
+}
+
+// registrationState is the state of the created instance of a Registration
+type registrationState struct {
+	instance   interface{}
+	cleanup    func() error
+	createdSeq uint64
+}
+
+// Snapshot saves the registrations and the installed modules of this scope and the state of their created instances
+// to be restored later.
+// Kindly note: the parent scopes are not part of the snapshot.
+func (s *Scope) Snapshot() Snapshot {
+	result := Snapshot{
+		registrations: s.Registrations(),
+		retired:       append(Registrations(nil), s.retired...),
+		installed:     map[*Module]bool{},
+		states:        map[*Registration]registrationState{},
+	}
+	for module := range s.installed {
+		result.installed[module] = true
+	}
+	for _, reg := range append(s.Registrations(), s.retired...) {
+		result.states[reg] = registrationState{instance: reg.instance, cleanup: reg.cleanup, createdSeq: reg.createdSeq}
+	}
+	return result
+}
+
+// Restore rolls back the registrations and the installed modules of this scope and their created instances to the
+// snapshot, i.e. modules installed after the snapshot was taken can be installed again.
+// The cleanup functions of instances created after the snapshot was taken are called in reverse creation order.
+func (s *Scope) Restore(snapshot Snapshot) error {
+	discarded := append(s.Registrations(), s.retired...).filter(func(reg *Registration) bool {
+		return reg.cleanup != nil && reg.createdSeq != snapshot.states[reg].createdSeq
+	})
+	err := closeRegistrations(discarded)
+	s.registrations = append(Registrations(nil), snapshot.registrations...)
+	s.retired = append(Registrations(nil), snapshot.retired...)
+	s.installed = map[*Module]bool{}
+	for module := range snapshot.installed {
+		s.installed[module] = true
+	}
+	for reg, state := range snapshot.states {
+		reg.instance, reg.cleanup, reg.createdSeq = state.instance, state.cleanup, state.createdSeq
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+package di_test
+
+import (
+	"errors"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Snapshot", func() {
+	var sut *di.Scope
+	var cleaned []string
+	BeforeEach(func() {
+		sut = &di.Scope{}
+		cleaned = nil
+	})
+	register := func(val ValueA) *di.Registration {
+		return sut.MustRegister(func() (ValueA, func(), error) {
+			return val, func() { cleaned = append(cleaned, string(val)) }, nil
+		})
+	}
+	It("should restore the registrations", func() {
+		register("a")
+		snapshot := sut.Snapshot()
+		register("b").WithPriority(-1)
+		Expect(sut.Restore(snapshot)).To(Succeed())
+		instance := &ComponentA1{}
+		sut.MustWire(instance)
+		Expect(instance.A).To(BeEquivalentTo("a"))
+	})
+	It("should restore removed registrations", func() {
+		reg := register("a")
+		snapshot := sut.Snapshot()
+		Expect(sut.Unregister(reg)).To(Succeed())
+		Expect(sut.Restore(snapshot)).To(Succeed())
+		Expect(sut.Registrations()).To(Equal(di.Registrations{reg}))
+	})
+	It("should restore the installed modules", func() {
+		installed := &di.Module{Name: "installed", Register: func(scope *di.Scope) error {
+			scope.MustRegister(ValueA("a"))
+			return nil
+		}}
+		module := &di.Module{Name: "module", Register: func(scope *di.Scope) error {
+			scope.MustRegister(ValueB("b"))
+			return nil
+		}}
+		sut.MustInstall(installed)
+		snapshot := sut.Snapshot()
+		sut.MustInstall(module)
+		Expect(sut.Restore(snapshot)).To(Succeed())
+		sut.MustInstall(installed, module)
+		Expect(sut.Registrations()).To(HaveLen(2))
+		Expect(sut.Registrations()[1].Module()).To(BeIdenticalTo(module))
+	})
+	It("should restore created instances and clean up discarded ones", func() {
+		sut.MustRegister(ValueB("b"))
+		register("a")
+		sut.MustRegister(func() *ComponentA1 { return &ComponentA1{} })
+		first := &struct {
+			B ValueB `inject:""`
+		}{}
+		sut.MustWire(first)
+		snapshot := sut.Snapshot()
+		created := &struct {
+			C *ComponentA1 `inject:""`
+		}{}
+		sut.MustWire(created)
+		Expect(cleaned).To(BeEmpty())
+		Expect(sut.Restore(snapshot)).To(Succeed())
+		Expect(cleaned).To(Equal([]string{"a"}))
+		restored := &struct {
+			C *ComponentA1 `inject:""`
+		}{}
+		sut.MustWire(restored)
+		Expect(restored.C).NotTo(BeIdenticalTo(created.C))
+	})
+	It("should return errors from clean up", func() {
+		sut.MustRegister(func() (ValueA, func() error, error) {
+			return "a", func() error { return errors.New("meh") }, nil
+		})
+		snapshot := sut.Snapshot()
+		sut.MustWire(&ComponentA1{})
+		Expect(sut.Restore(snapshot)).To(MatchError(ContainSubstring("meh")))
+	})
+})
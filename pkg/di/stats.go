@@ -0,0 +1,124 @@
+package di
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// RegistrationStats are the statistics of a Registration recorded by a Scope
+type RegistrationStats struct {
+	// Registration is the registration the statistics belong to
+	Registration *Registration
+	// FactoryDuration is the time the factory call took, excluding the creation and wiring of its dependencies
+	FactoryDuration time.Duration
+	// WiringDuration is the time the wiring of the created instance took, excluding the creation and wiring of its
+	// dependencies
+	WiringDuration time.Duration
+	// Injections is the number of times the registration has been resolved
+	Injections int
+	// InjectedInto are the distinct types the registration has been injected into, in order of the first injection.
+	// Factories are denoted by the type of their registration, invoked functions by their function type.
+	InjectedInto []reflect.Type
+}
+
+// Duration is the sum of the factory and the wiring duration, i.e. the time spent for the registration itself
+func (r *RegistrationStats) Duration() time.Duration {
+	return r.FactoryDuration + r.WiringDuration
+}
+
+func (r *RegistrationStats) injectedInto(consumer reflect.Type) {
+	r.Injections++
+	if consumer == nil {
+		return
+	}
+	for _, tpe := range r.InjectedInto {
+		if tpe == consumer {
+			return
+		}
+	}
+	r.InjectedInto = append(r.InjectedInto, consumer)
+}
+
+// Stats are the statistics of the registrations of a Scope
+type Stats []*RegistrationStats
+
+// Slowest returns a copy of the statistics sorted by duration, the slowest first
+func (s Stats) Slowest() Stats {
+	result := append(Stats(nil), s...)
+	sort.SliceStable(result, func(i, j int) bool { return result[i].Duration() > result[j].Duration() })
+	return result
+}
+
+// String returns the statistics as table of the slowest components
+func (s Stats) String() string {
+	var sb strings.Builder
+	writer := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0) // nolint:gomnd
+	_, _ = fmt.Fprintln(writer, "COMPONENT\tTOTAL\tFACTORY\tWIRING\tINJECTIONS\tINJECTED INTO\tSOURCE")
+	for _, stats := range s.Slowest() {
+		reg := stats.Registration
+		name := reg.Type.String()
+		if len(reg.Qualifier) > 0 {
+			name = fmt.Sprintf("%v(%v)", name, reg.Qualifier)
+		}
+		consumers := make([]string, len(stats.InjectedInto))
+		for idx, consumer := range stats.InjectedInto {
+			consumers[idx] = consumer.String()
+		}
+		_, _ = fmt.Fprintf(writer, "%v\t%v\t%v\t%v\t%v\t%v\t%v\n", name, stats.Duration(), stats.FactoryDuration,
+			stats.WiringDuration, stats.Injections, strings.Join(consumers, ", "), reg.Source)
+	}
+	_ = writer.Flush()
+	return sb.String()
+}
+
+// Stats returns the statistics of the registrations of this scope in order of registration. Kindly note: the
+// statistics of a registration are recorded by the scope owning it, including its resolutions by child scopes.
+func (s *Scope) Stats() Stats {
+	s.statsMutex.Lock()
+	defer s.statsMutex.Unlock()
+	result := make(Stats, 0, len(s.registrations))
+	for _, reg := range s.registrations {
+		result = append(result, s.statsOf(reg))
+	}
+	return result
+}
+
+// statsOf returns a copy of the recorded statistics of the registration
+func (s *Scope) statsOf(registration *Registration) *RegistrationStats {
+	result := RegistrationStats{Registration: registration}
+	if recorded, found := s.stats[registration]; found {
+		result = *recorded
+		result.InjectedInto = append([]reflect.Type(nil), recorded.InjectedInto...)
+	}
+	return &result
+}
+
+// recordStats updates the statistics of the registration in the scope owning it, concurrent calls are synchronized
+func (s *Scope) recordStats(registration *Registration, update func(stats *RegistrationStats)) {
+	owner := s.ownerOf(registration)
+	owner.statsMutex.Lock()
+	defer owner.statsMutex.Unlock()
+	if owner.stats == nil {
+		owner.stats = map[*Registration]*RegistrationStats{}
+	}
+	result, found := owner.stats[registration]
+	if !found {
+		result = &RegistrationStats{Registration: registration}
+		owner.stats[registration] = result
+	}
+	update(result)
+}
+
+// ownerOf returns the nearest of the scope and its parents containing the registration, the scope itself if none does
+func (s *Scope) ownerOf(registration *Registration) *Scope {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if scope.registrations.contains(registration) {
+			return scope
+		}
+	}
+	return s
+}
@@ -0,0 +1,72 @@
+package di_test
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stats", func() {
+	var sut *di.Scope
+	var regA, regA1, regB1 *di.Registration
+	BeforeEach(func() {
+		sut = &di.Scope{}
+		regA = sut.MustRegister(func() ValueA {
+			time.Sleep(10 * time.Millisecond)
+			return "a"
+		})
+		regA1 = sut.MustRegister(func() *ComponentA1 { return &ComponentA1{} })
+		regB1 = sut.MustRegister(&ComponentB1{})
+	})
+	It("should record the statistics of the registrations", func() {
+		sut.MustWire(&ComponentB1{}, &ComponentA2{})
+		_ = sut.MustInvoke(func(ValueA) {})
+		stats := sut.Stats()
+		Expect(stats).To(HaveLen(3))
+		Expect(stats[0].Registration).To(Equal(regA))
+		Expect(stats[0].FactoryDuration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(stats[0].WiringDuration).To(BeZero())
+		Expect(stats[0].Injections).To(Equal(2))
+		Expect(stats[0].InjectedInto).To(Equal([]reflect.Type{
+			reflect.TypeOf(&ComponentA1{}), reflect.TypeOf(func(ValueA) {}),
+		}))
+		Expect(stats[1].Registration).To(Equal(regA1))
+		// the creation of ValueA is excluded
+		Expect(stats[1].WiringDuration).To(BeNumerically("<", 10*time.Millisecond))
+		Expect(stats[1].Duration()).To(Equal(stats[1].FactoryDuration + stats[1].WiringDuration))
+		Expect(stats[1].InjectedInto).To(Equal([]reflect.Type{reflect.TypeOf(&ComponentB1{})}))
+		Expect(stats[2].Registration).To(Equal(regB1))
+		Expect(stats[2].Injections).To(BeZero())
+	})
+	It("should exclude the creation of the factory parameters", func() {
+		regB := sut.MustRegister(func(InA) ValueB { return "b" })
+		_ = sut.MustInvoke(func(ValueB) {})
+		stats := sut.Stats()
+		Expect(stats[0].FactoryDuration).To(BeNumerically(">=", 10*time.Millisecond))
+		Expect(stats[3].Registration).To(Equal(regB))
+		Expect(stats[3].FactoryDuration).To(BeNumerically("<", 10*time.Millisecond))
+		Expect(stats.Slowest()[0].Registration).To(Equal(regA))
+	})
+	It("should record the statistics of registrations resolved by a child in the owning scope", func() {
+		child := sut.NewChild()
+		child.MustWire(&ComponentB1{})
+		Expect(child.Stats()).To(BeEmpty())
+		stats := sut.Stats()
+		Expect(stats[0].Registration).To(Equal(regA))
+		Expect(stats[0].InjectedInto).To(Equal([]reflect.Type{reflect.TypeOf(&ComponentA1{})}))
+		Expect(stats[1].Registration).To(Equal(regA1))
+		Expect(stats[1].InjectedInto).To(Equal([]reflect.Type{reflect.TypeOf(&ComponentB1{})}))
+	})
+	It("should print the slowest components", func() {
+		sut.MustWire(&ComponentB1{})
+		Expect(sut.Stats().Slowest()[0].Registration).To(Equal(regA))
+		Expect(sut.Stats().String()).To(MatchRegexp(
+			`^COMPONENT\s+TOTAL\s+FACTORY\s+WIRING\s+INJECTIONS\s+INJECTED INTO\s+SOURCE\n` +
+				`di_test.ValueA\s+\S+\s+\S+\s+0s\s+1\s+\*di_test.ComponentA1\s+\S+stats_test.go:\d+\n` +
+				`\*di_test.ComponentA1\s+\S+\s+\S+\s+\S+\s+1\s+\*di_test.ComponentB1\s+\S+stats_test.go:\d+\n` +
+				`\*di_test.ComponentB1\s+0s\s+0s\s+0s\s+0\s+\S+stats_test.go:\d+\n$`))
+	})
+})
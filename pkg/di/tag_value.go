@@ -5,6 +5,8 @@ import "strings"
 const (
 	TagPrefixQualifier = "qualifier="
 	TagValueOptional   = "optional"
+	TagValueLocal      = "local"
+	TagValueParent     = "parent"
 	AllQualifiers      = "*"
 )
 
@@ -14,6 +16,8 @@ type TagValue struct {
 	Qualifier string
 	// Required denotes if the injection is required and at least a single instance is necessary
 	Required bool
+	// Lookup overrides the LookupStrategy of the scopes, if not LookupDefault
+	Lookup LookupStrategy
 }
 
 func (v TagValue) IsAllQualifier() bool {
@@ -27,6 +31,12 @@ func TagValueFrom(val string) TagValue {
 		if part == TagValueOptional {
 			result.Required = false
 		}
+		if part == TagValueLocal {
+			result.Lookup = LookupChildFirst
+		}
+		if part == TagValueParent {
+			result.Lookup = LookupParentFirst
+		}
 		if strings.HasPrefix(part, TagPrefixQualifier) {
 			result.Qualifier = part[len(TagPrefixQualifier):]
 		}
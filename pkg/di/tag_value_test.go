@@ -17,6 +17,10 @@ var _ = Describe("TagValueFrom()", func() {
 	It("should parse all options, skipping invalid", func() {
 		Expect(di.TagValueFrom("qualifier=meh,optional,meh")).To(Equal(di.TagValue{Required: false, Qualifier: "meh"}))
 	})
+	It("should parse lookup options", func() {
+		Expect(di.TagValueFrom("local").Lookup).To(Equal(di.LookupChildFirst))
+		Expect(di.TagValueFrom("parent").Lookup).To(Equal(di.LookupParentFirst))
+	})
 	It("should parse all qualifiers selector", func() {
 		Expect(di.TagValueFrom("qualifier=*").IsAllQualifier()).To(BeTrue())
 	})
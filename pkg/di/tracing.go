@@ -0,0 +1,45 @@
+package di
+
+import (
+	"context"
+	"strconv"
+)
+
+const (
+	// SpanNameFactory is the name of the span for the factory call of a registration
+	SpanNameFactory = "di.factory"
+	// SpanNameWire is the name of the span for the wiring of a target
+	SpanNameWire = "di.wire"
+
+	TraceAttributeType      = "di.type"
+	TraceAttributeQualifier = "di.qualifier"
+	TraceAttributePriority  = "di.priority"
+	TraceAttributeSource    = "di.source"
+)
+
+// TraceAttribute is a key value pair describing a span
+type TraceAttribute struct {
+	Key   string
+	Value string
+}
+
+// Tracer starts spans for factory calls and wiring, e.g. an adapter for OpenTelemetry
+type Tracer interface {
+	// Start starts a span as child of the span contained in ctx and returns a context containing the new span
+	Start(ctx context.Context, name string, attributes ...TraceAttribute) (context.Context, Span)
+}
+
+// Span is a started span of a Tracer
+type Span interface {
+	// End ends the span, recording the error if not nil
+	End(err error)
+}
+
+func traceAttributesOf(registration *Registration) []TraceAttribute {
+	return []TraceAttribute{
+		{Key: TraceAttributeType, Value: registration.Type.String()},
+		{Key: TraceAttributeQualifier, Value: registration.Qualifier},
+		{Key: TraceAttributePriority, Value: strconv.Itoa(registration.Priority)},
+		{Key: TraceAttributeSource, Value: registration.Source},
+	}
+}
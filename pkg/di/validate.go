@@ -0,0 +1,42 @@
+package di
+
+// ValidateOption is a check of a Scope performed by Scope#Validate
+type ValidateOption func(scope *Scope) error
+
+// ValidateUnused fails for registrations of the scope never selected to be injected, see Scope#Unused.
+// Kindly note: this check is meant to be performed after the application has wired its roots.
+func ValidateUnused() ValidateOption {
+	return func(scope *Scope) error {
+		if unused := scope.Unused(); len(unused) > 0 {
+			return errUnusedRegistrations(unused)
+		}
+		return nil
+	}
+}
+
+// Validate performs the checks of the options, the errors of all failed checks are aggregated
+func (s *Scope) Validate(opts ...ValidateOption) error {
+	var errs Errors
+	for _, opt := range opts {
+		if err := opt(s); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs.errOrNil()
+}
+
+// Unused returns the registrations of this scope which have never been selected to be injected by this scope or one of
+// its children. A registration providing a result object (see Out) is used, if one of its Results has been selected.
+func (s *Scope) Unused() Registrations {
+	return s.registrations.filter(func(reg *Registration) bool {
+		if reg.isSelected() {
+			return false
+		}
+		for _, result := range reg.Results {
+			if result.isSelected() {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -0,0 +1,67 @@
+package di_test
+
+import (
+	"sync"
+
+	"github.com/dbsystel/golang-runtime-di/pkg/di"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Validate", func() {
+	var sut *di.Scope
+	BeforeEach(func() {
+		sut = &di.Scope{}
+	})
+	It("should succeed without options", func() {
+		sut.MustRegister(ValueA("a"))
+		Expect(sut.Validate()).To(Succeed())
+	})
+	It("should fail for unused registrations", func() {
+		sut.MustRegister(ValueA("a"))
+		unused := sut.MustRegister(ValueB("b"))
+		sut.MustWire(&ComponentA2{}, &struct {
+			A ValueA `inject:""`
+		}{})
+		Expect(sut.Unused()).To(Equal(di.Registrations{unused}))
+		err := sut.Validate(di.ValidateUnused())
+		Expect(err).To(MatchError(ContainSubstring("unused registrations:")))
+		Expect(err).To(MatchError(ContainSubstring("validate_test.go:")))
+	})
+	It("should consider registrations selected by child scopes", func() {
+		sut.MustRegister(ValueA("a"))
+		sut.NewChild().MustWire(&ComponentA1{})
+		Expect(sut.Validate(di.ValidateUnused())).To(Succeed())
+	})
+	It("should consider registrations selected by concurrent child scopes", func() {
+		_, _, err := sut.MustRegister(ValueA("a")).GetInstance()
+		Expect(err).NotTo(HaveOccurred())
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer GinkgoRecover()
+				defer wg.Done()
+				sut.NewChild().MustWire(&ComponentA1{})
+			}()
+		}
+		wg.Wait()
+		Expect(sut.Validate(di.ValidateUnused())).To(Succeed())
+	})
+	It("should consider result objects used if one of their results has been selected", func() {
+		sut.MustRegister(func() OutAB { return OutAB{} })
+		sut.MustWire(&struct {
+			B ValueB `inject:""`
+		}{})
+		unused := sut.Unused()
+		Expect(unused).To(HaveLen(2))
+		Expect(unused[0].Qualifier).To(BeEmpty())
+		Expect(unused[1].Qualifier).To(Equal("a2"))
+	})
+	It("should aggregate the errors", func() {
+		sut.MustRegister(ValueA("a"))
+		err := sut.Validate(di.ValidateUnused(), func(*di.Scope) error { return nil }, di.ValidateUnused())
+		Expect(err).To(BeAssignableToTypeOf(di.Errors{}))
+		Expect(err.(di.Errors)).To(HaveLen(2))
+	})
+})